@@ -0,0 +1,91 @@
+package oremus
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// DefaultConcurrency is the number of requests GetMany makes at once unless WithConcurrency is used
+const DefaultConcurrency = 4
+
+// Result is the outcome of fetching one reference with GetMany
+type Result struct {
+	Reference string // the reference as passed to GetMany
+	Passage   *Passage
+	Err       error
+}
+
+// WithConcurrency sets the number of requests GetMany makes at once
+func WithConcurrency(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// GetMany fetches several passages using the default Client
+func GetMany(ctx context.Context, refs []string) []Result {
+	return defaultClient.GetMany(ctx, refs)
+}
+
+// GetMany fetches several passages at once, returning one Result per reference in the order given
+// references that normalize to the same passage are fetched once and share the *Passage
+// if ctx is cancelled, outstanding requests are abandoned and their Results carry ctx.Err()
+func (c *Client) GetMany(ctx context.Context, refs []string) []Result {
+	// dedupe, slot[i] is the index in unique for refs[i]
+	index := make(map[string]int)
+	slot := make([]int, len(refs))
+	var unique []string
+	for i, ref := range refs {
+		key := strings.TrimSpace(ref)
+		if clean, err := CleanReference(ref); err == nil {
+			key = clean
+		}
+		u, ok := index[key]
+		if !ok {
+			u = len(unique)
+			index[key] = u
+			unique = append(unique, ref)
+		}
+		slot[i] = u
+	}
+
+	fetched := make([]Result, len(unique))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(c.concurrency, len(unique)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for u := range jobs {
+				p, err := c.GetPassage(ctx, unique[u])
+				fetched[u] = Result{Passage: p, Err: err}
+			}
+		}()
+	}
+
+feed:
+	for u := range unique {
+		select {
+		case jobs <- u:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	out := make([]Result, len(refs))
+	for i, ref := range refs {
+		r := fetched[slot[i]]
+		if r.Passage == nil && r.Err == nil {
+			// never started
+			r.Err = ctx.Err()
+		}
+		r.Reference = ref
+		out[i] = r
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+package oremus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetMany(t *testing.T) {
+	var mu sync.Mutex
+	var active, peak int
+	requested := make(map[string]int)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		active++
+		peak = max(peak, active)
+		requested[r.FormValue("passage")]++
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(`<div class="bibletext"><p>` + r.FormValue("passage") + `</p></div>`))
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+	}))
+	defer ts.Close()
+
+	c := NewClient(WithBaseURL(ts.URL), WithConcurrency(2))
+	refs := []string{"Genesis 1", "Exodus 2", "gen 1", "Leviticus 3", "Numbers 4", "Deuteronomy 5"}
+	results := c.GetMany(context.Background(), refs)
+
+	if len(results) != len(refs) {
+		t.Fatalf("expected %d results, got %d", len(refs), len(results))
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			t.Fatalf("%s: unexpected error %v", r.Reference, r.Err)
+		}
+		if r.Reference != refs[i] {
+			t.Errorf("result %d out of order: %s", i, r.Reference)
+		}
+	}
+	if results[0].Passage != results[2].Passage {
+		t.Errorf("expected duplicate references to share a passage")
+	}
+	if got := results[1].Passage.Paragraphs[0].Lines[0].Runs[0].Text; got != "Exodus 2" {
+		t.Errorf("wrong passage for Exodus 2: %q", got)
+	}
+
+	if len(requested) != 5 || requested["Genesis 1"] != 1 || requested["gen 1"] != 0 {
+		t.Errorf("expected each passage fetched once, got %v", requested)
+	}
+	if peak > 2 {
+		t.Errorf("concurrency limit exceeded: %d", peak)
+	}
+}
+
+func TestGetManyErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("passage") == "Exodus 2" {
+			http.Error(w, "no", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(minimalLection))
+	}))
+	defer ts.Close()
+
+	c := NewClient(WithBaseURL(ts.URL))
+	results := c.GetMany(context.Background(), []string{"Genesis 1", "Exodus 2"})
+
+	if results[0].Err != nil || results[0].Passage == nil {
+		t.Errorf("expected Genesis 1 to succeed: %v", results[0].Err)
+	}
+	var se *HTTPStatusError
+	if !errors.As(results[1].Err, &se) || results[1].Passage != nil {
+		t.Errorf("expected Exodus 2 to fail with an HTTPStatusError: %v", results[1].Err)
+	}
+}
+
+func TestGetManyCancel(t *testing.T) {
+	var started atomic.Int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started.Add(1)
+		<-release
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := NewClient(WithBaseURL(ts.URL), WithConcurrency(2))
+	refs := []string{"Genesis 1", "Exodus 2", "Leviticus 3", "Numbers 4", "Deuteronomy 5"}
+
+	start := time.Now()
+	results := c.GetMany(ctx, refs)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("GetMany took %s to notice the cancellation", elapsed)
+	}
+	for _, r := range results {
+		if !errors.Is(r.Err, context.DeadlineExceeded) {
+			t.Errorf("%s: expected deadline exceeded, got %v", r.Reference, r.Err)
+		}
+	}
+	if started.Load() > 2 {
+		t.Errorf("expected at most 2 requests to start, got %d", started.Load())
+	}
+}
@@ -0,0 +1,80 @@
+package oremus
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache stores raw oremus responses so repeated requests for a passage do not hit the server
+// keys are built by the Client from the normalized reference, the version and the form options
+// implementations must be safe for concurrent use
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte) error
+}
+
+// LRUCache is an in-memory Cache holding a fixed number of responses, discarding the least recently used
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // front is most recently used
+	entries map[string]*list.Element
+}
+
+// lruEntry is the value stored in the list
+type lruEntry struct {
+	key   string
+	value []byte
+}
+
+// NewLRUCache returns an LRUCache holding up to size responses
+func NewLRUCache(size int) *LRUCache {
+	if size < 1 {
+		size = 1
+	}
+	return &LRUCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached response for key
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry).value, true
+}
+
+// Set stores the response for key, evicting the least recently used entry if the cache is full
+func (c *LRUCache) Set(key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lruEntry).value = value
+		c.order.MoveToFront(e)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+// Len returns the number of cached responses
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,139 @@
+package oremus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	c := NewLRUCache(2)
+	c.Set("a", []byte("1"))
+	c.Set("b", []byte("2"))
+
+	// touch a so b is the oldest
+	if v, ok := c.Get("a"); !ok || string(v) != "1" {
+		t.Fatalf("expected a=1, got %q %v", v, ok)
+	}
+	c.Set("c", []byte("3"))
+
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Errorf("expected a to survive")
+	}
+	if v, ok := c.Get("c"); !ok || string(v) != "3" {
+		t.Errorf("expected c=3, got %q %v", v, ok)
+	}
+	if c.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", c.Len())
+	}
+
+	c.Set("c", []byte("4"))
+	if v, _ := c.Get("c"); string(v) != "4" {
+		t.Errorf("expected overwrite, got %q", v)
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewFileCache(filepath.Join(dir, "cache"), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := c.Get("passage=Genesis+1"); ok {
+		t.Errorf("expected miss on empty cache")
+	}
+	if err := c.Set("passage=Genesis+1", []byte("in the beginning")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, ok := c.Get("passage=Genesis+1"); !ok || string(v) != "in the beginning" {
+		t.Errorf("expected hit, got %q %v", v, ok)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "cache"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected a single file and no temporaries, got %d", len(entries))
+	}
+}
+
+func TestFileCacheTTL(t *testing.T) {
+	c, err := NewFileCache(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Set("k", []byte("v"))
+	if _, ok := c.Get("k"); !ok {
+		t.Fatalf("expected fresh entry to hit")
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(c.path("k"), old, old); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := c.Get("k"); ok {
+		t.Errorf("expected expired entry to miss")
+	}
+	if _, err := os.Stat(c.path("k")); !os.IsNotExist(err) {
+		t.Errorf("expected expired entry to be removed")
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	var hits atomic.Int32
+	body := fixture(t, "psalm-23.html")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	cache := NewLRUCache(10)
+	c := NewClient(WithBaseURL(ts.URL), WithCache(cache))
+	ctx := context.Background()
+
+	for _, ref := range []string{"Psalm 23", "psalm 23", " PSALM  23 "} {
+		p, err := c.GetPassage(ctx, ref)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(p.Paragraphs) != 3 {
+			t.Errorf("cached passage not parsed: %d paragraphs", len(p.Paragraphs))
+		}
+	}
+	if hits.Load() != 1 {
+		t.Errorf("expected one request, got %d", hits.Load())
+	}
+
+	// different options are a different key
+	c2 := NewClient(WithBaseURL(ts.URL), WithCache(cache), WithVerseNumbers(true))
+	if _, err := c2.Get(ctx, "Psalm 23"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hits.Load() != 2 {
+		t.Errorf("expected a second request, got %d", hits.Load())
+	}
+}
+
+func TestClientCacheSkipsErrors(t *testing.T) {
+	ts := fixtureServer(t, "not-found.html")
+	cache := NewLRUCache(10)
+	c := NewClient(WithBaseURL(ts.URL), WithCache(cache))
+
+	if _, err := c.Get(context.Background(), "Hezekiah 4:1"); err == nil {
+		t.Fatalf("expected error")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("error pages should not be cached")
+	}
+}
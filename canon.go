@@ -0,0 +1,147 @@
+package oremus
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+)
+
+// Canon selects the order of the books of the Bible
+type Canon int
+
+const (
+	Protestant Canon = iota // the NRSV's order, with the Apocrypha between the testaments
+	Catholic                // deuterocanonical books in place, the remaining Apocrypha after the New Testament
+	Orthodox                // the Septuagint order of the Greek Orthodox church
+)
+
+// runs of books every canon keeps in the same order
+var (
+	pentateuch    = []BookCode{"GEN", "EXO", "LEV", "NUM", "DEU"}
+	newTestament  = []BookCode{"MAT", "MRK", "LUK", "JHN", "ACT", "ROM", "1CO", "2CO", "GAL", "EPH", "PHP", "COL", "1TH", "2TH", "1TI", "2TI", "TIT", "PHM", "HEB", "JAS", "1PE", "2PE", "1JN", "2JN", "3JN", "JUD", "REV"}
+	minorProphets = []BookCode{"HOS", "JOL", "AMO", "OBA", "JON", "MIC", "NAM", "HAB", "ZEP", "HAG", "ZEC", "MAL"}
+)
+
+// canons lists the books of each canon in order
+var canons = map[Canon][]BookCode{
+	Protestant: slices.Concat(
+		pentateuch,
+		[]BookCode{"JOS", "JDG", "RUT", "1SA", "2SA", "1KI", "2KI", "1CH", "2CH", "EZR", "NEH", "EST", "JOB", "PSA", "PRO", "ECC", "SNG", "ISA", "JER", "LAM", "EZK", "DAN"},
+		minorProphets,
+		[]BookCode{"TOB", "JDT", "ESG", "WIS", "SIR", "BAR", "S3Y", "SUS", "BEL", "1MA", "2MA", "1ES", "MAN", "3MA", "2ES", "4MA"},
+		newTestament,
+	),
+	Catholic: slices.Concat(
+		pentateuch,
+		[]BookCode{"JOS", "JDG", "RUT", "1SA", "2SA", "1KI", "2KI", "1CH", "2CH", "EZR", "NEH", "TOB", "JDT", "EST", "ESG", "1MA", "2MA", "JOB", "PSA", "PRO", "ECC", "SNG", "WIS", "SIR", "ISA", "JER", "LAM", "BAR", "EZK", "DAN", "S3Y", "SUS", "BEL"},
+		minorProphets,
+		newTestament,
+		[]BookCode{"MAN", "1ES", "2ES", "3MA", "4MA"},
+	),
+	Orthodox: slices.Concat(
+		pentateuch,
+		[]BookCode{"JOS", "JDG", "RUT", "1SA", "2SA", "1KI", "2KI", "1CH", "2CH", "MAN", "1ES", "EZR", "NEH", "TOB", "JDT", "EST", "ESG", "1MA", "2MA", "3MA", "PSA", "JOB", "PRO", "ECC", "SNG", "WIS", "SIR"},
+		[]BookCode{"HOS", "AMO", "MIC", "JOL", "OBA", "JON", "NAM", "HAB", "ZEP", "HAG", "ZEC", "MAL"},
+		[]BookCode{"ISA", "JER", "BAR", "LAM", "EZK", "DAN", "S3Y", "SUS", "BEL", "4MA", "2ES"},
+		newTestament,
+	),
+}
+
+// ordinals maps each canon's books to their 1-based position
+var ordinals = make(map[Canon]map[BookCode]int)
+
+func init() {
+	for c, order := range canons {
+		ordinals[c] = make(map[BookCode]int, len(order))
+		for i, code := range order {
+			ordinals[c][code] = i + 1
+		}
+	}
+}
+
+// String returns the name of the canon
+func (c Canon) String() string {
+	switch c {
+	case Protestant:
+		return "Protestant"
+	case Catholic:
+		return "Catholic"
+	case Orthodox:
+		return "Orthodox"
+	default:
+		return "Canon(" + strconv.Itoa(int(c)) + ")"
+	}
+}
+
+// Ordinal returns the 1-based position of the reference's book in the canon, 0 if it is unknown
+func (r *Reference) Ordinal(c Canon) int {
+	code, ok := r.BookCode()
+	if !ok {
+		return 0
+	}
+	return ordinals[c][code]
+}
+
+// Compare orders references by book in the Protestant canon, then chapter, verse and suffix
+// it returns -1, 0 or +1 like cmp.Compare
+func (r *Reference) Compare(other *Reference) int {
+	return Protestant.Compare(r, other)
+}
+
+// Compare orders references by book in the canon, then chapter, verse and suffix
+// a whole book sorts before its chapters, a whole chapter before its verses; books not in the canon sort last
+func (c Canon) Compare(a, b *Reference) int {
+	ao, bo := a.Ordinal(c), b.Ordinal(c)
+	if ao == 0 {
+		ao = len(canons[c]) + 1
+	}
+	if bo == 0 {
+		bo = len(canons[c]) + 1
+	}
+	if n := cmp.Compare(ao, bo); n != 0 {
+		return n
+	}
+	if a.Ordinal(c) == 0 {
+		if n := cmp.Compare(a.bookName(), b.bookName()); n != 0 {
+			return n
+		}
+	}
+	return slices.CompareFunc(a.ChapterVerseRange, b.ChapterVerseRange, compareRange)
+}
+
+// compareRange orders ranges by where they start, then where they end
+func compareRange(a, b ChapterVerseRange) int {
+	return cmp.Or(
+		cmp.Compare(a.StartChapter, b.StartChapter),
+		cmp.Compare(a.StartVerse, b.StartVerse),
+		cmp.Compare(suffixOrder(a.StartVerseSuffix), suffixOrder(b.StartVerseSuffix)),
+		cmp.Compare(a.EndChapter, b.EndChapter),
+		cmp.Compare(a.EndVerse, b.EndVerse),
+		cmp.Compare(endSuffixOrder(a.EndVerseSuffix), endSuffixOrder(b.EndVerseSuffix)),
+	)
+}
+
+// suffixOrder ranks verse suffixes at the start of a range: a whole verse starts with its first half
+func suffixOrder(s rune) int {
+	if s == 'b' {
+		return 1
+	}
+	return 0
+}
+
+// endSuffixOrder ranks verse suffixes at the end of a range: a whole verse ends with its second half, ff runs on
+func endSuffixOrder(s rune) int {
+	switch s {
+	case 'a':
+		return 1
+	case 'f':
+		return 3
+	default:
+		return 2
+	}
+}
+
+// SortReferences sorts references into the order of the canon, keeping equal references in their original order
+func SortReferences(refs []*Reference, c Canon) {
+	slices.SortStableFunc(refs, c.Compare)
+}
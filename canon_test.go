@@ -0,0 +1,104 @@
+package oremus
+
+import (
+	"testing"
+)
+
+func TestCanonsComplete(t *testing.T) {
+	for _, c := range []Canon{Protestant, Catholic, Orthodox} {
+		if len(ordinals[c]) != len(canons[c]) {
+			t.Errorf("%s: duplicate books", c)
+		}
+		for name, code := range bookCodes {
+			if ordinals[c][code] == 0 {
+				t.Errorf("%s: %s is missing", c, name)
+			}
+		}
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	tests := []struct {
+		ref   string
+		canon Canon
+		want  int
+	}{
+		{"gen 1", Protestant, 1},
+		{"mal 4", Protestant, 39},
+		{"matt 1", Protestant, 56},
+		{"rev 22", Protestant, 82},
+		{"tobit 1", Protestant, 40},
+		{"tobit 1", Catholic, 17},
+		{"1 macc 1", Catholic, 21},
+		{"psalm 23", Catholic, 24},
+		{"matt 1", Catholic, 51},
+		{"psalm 23", Orthodox, 26},
+	}
+	for _, tc := range tests {
+		r, err := ParseReference(tc.ref)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.ref, err)
+		}
+		if got := r.Ordinal(tc.canon); got != tc.want {
+			t.Errorf("%s in the %s canon: got %d, want %d", tc.ref, tc.canon, got, tc.want)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"gen 1:1", "gen 1:1", 0},
+		{"gen 1:1", "ex 1:1", -1},
+		{"rev 1", "gen 50", 1},
+		{"gen 2", "gen 1:5", 1},
+		{"gen 1", "gen 1:1", -1},
+		{"genesis", "gen 1", -1},
+		{"gen 1:1a", "gen 1:1b", -1},
+		{"gen 1:1-3", "gen 1:1-5", -1},
+		{"gen 1:1-5a", "gen 1:1-5", -1},
+		{"gen 1:1-5", "gen 1:1-5,7", -1},
+		{"psalm 23", "psalms 23", 0},
+		{"1 john 1", "2 john 1", -1},
+		{"john 1", "1 john 1", -1},
+		{"mal 1", "tobit 1", -1},
+		{"tobit 1", "matt 1", -1},
+	}
+	for _, tc := range tests {
+		a, err := ParseReference(tc.a)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.a, err)
+		}
+		b, err := ParseReference(tc.b)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.b, err)
+		}
+		if got := a.Compare(b); got != tc.want {
+			t.Errorf("compare %s to %s: got %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+		if got := b.Compare(a); got != -tc.want {
+			t.Errorf("compare %s to %s: got %d, want %d", tc.b, tc.a, got, -tc.want)
+		}
+	}
+}
+
+func TestSortReferences(t *testing.T) {
+	in := "rom 8:1-11; gen 2:4b-9; tobit 3; psalm 23; gen 1; 1 macc 2:1-28; john 3:16; gen 1:26-31; ps 1; sir 44; mark 1"
+	tests := map[Canon]string{
+		Protestant: "Genesis 1; Genesis 1:26-31; Genesis 2:4b-9; Psalms 1; Psalm 23; Tobit 3; Sirach 44; 1 Maccabees 2:1-28; Mark 1; John 3:16; Romans 8:1-11",
+		Catholic:   "Genesis 1; Genesis 1:26-31; Genesis 2:4b-9; Tobit 3; 1 Maccabees 2:1-28; Psalms 1; Psalm 23; Sirach 44; Mark 1; John 3:16; Romans 8:1-11",
+		Orthodox:   "Genesis 1; Genesis 1:26-31; Genesis 2:4b-9; Tobit 3; 1 Maccabees 2:1-28; Psalms 1; Psalm 23; Sirach 44; Mark 1; John 3:16; Romans 8:1-11",
+	}
+	for c, want := range tests {
+		refs, err := ParseReferences(in)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		SortReferences(refs, c)
+		if got := joinReferences(refs); got != want {
+			t.Errorf("%s: got %q, want %q", c, got, want)
+		}
+	}
+}
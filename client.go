@@ -0,0 +1,323 @@
+package oremus
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the address of the oremus Bible Browser
+const DefaultBaseURL = "https://bible.oremus.org/"
+
+// Client fetches passages from bible.oremus.org
+// the zero value is not usable, use NewClient
+type Client struct {
+	httpClient   *http.Client
+	baseURL      string
+	version      Version
+	verseNumbers bool
+	footnotes    bool
+	headings     bool
+	showRef      bool
+	showAdjacent bool
+	hiddenText   bool
+	cache        Cache
+	concurrency  int
+	userAgent    string
+	limiter      *limiter
+	retries      int
+	backoffBase  time.Duration
+	backoffMax   time.Duration
+	logger       *slog.Logger
+	renderer     Renderer
+	ffResolver   FFResolver
+	omitOptional bool
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// NewClient returns a Client configured with the given options
+// the defaults match the historical behavior of Get: no verse numbers, footnotes, headings, reference or adjacent-passage links, and hidden text omitted
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		httpClient:  http.DefaultClient,
+		baseURL:     DefaultBaseURL,
+		concurrency: DefaultConcurrency,
+		userAgent:   DefaultUserAgent,
+		backoffBase: defaultBackoffBase,
+		backoffMax:  defaultBackoffMax,
+		logger:      discardLogger,
+		renderer:    HTMLRenderer{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithVersion selects the translation, the default is the NRSV
+func WithVersion(v Version) Option {
+	return func(c *Client) {
+		c.version = v
+	}
+}
+
+// WithVerseNumbers turns verse numbers on or off
+func WithVerseNumbers(on bool) Option {
+	return func(c *Client) {
+		c.verseNumbers = on
+	}
+}
+
+// WithFootnotes turns footnotes on or off
+func WithFootnotes(on bool) Option {
+	return func(c *Client) {
+		c.footnotes = on
+	}
+}
+
+// WithHeadings turns section headings on or off
+func WithHeadings(on bool) Option {
+	return func(c *Client) {
+		c.headings = on
+	}
+}
+
+// WithShowReference turns the reference heading on or off
+func WithShowReference(on bool) Option {
+	return func(c *Client) {
+		c.showRef = on
+	}
+}
+
+// WithShowAdjacent turns the links to the previous and next passages on or off
+func WithShowAdjacent(on bool) Option {
+	return func(c *Client) {
+		c.showAdjacent = on
+	}
+}
+
+// WithHiddenText includes text oremus hides by default (e.g. bracketed later additions)
+func WithHiddenText(on bool) Option {
+	return func(c *Client) {
+		c.hiddenText = on
+	}
+}
+
+// WithHTTPClient sets the http.Client used to make requests
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc != nil {
+			c.httpClient = hc
+		}
+	}
+}
+
+// WithBaseURL sets the address requests are posted to, useful for testing or mirrors
+func WithBaseURL(u string) Option {
+	return func(c *Client) {
+		if u != "" {
+			c.baseURL = u
+		}
+	}
+}
+
+// WithCache stores responses in the cache and answers repeated requests from it
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithLogger sends the Client's diagnostics to logger, by default nothing is logged
+// requests are logged at Debug, retries at Info and cache failures at Warn; errors returned to the caller are not logged
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		if logger == nil {
+			logger = discardLogger
+		}
+		c.logger = logger
+	}
+}
+
+// yesno converts a bool to the values the oremus form expects
+func yesno(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// form builds the values posted to oremus for a passage
+func (c *Client) form(ref string) url.Values {
+	data := url.Values{}
+	data.Set("passage", strings.TrimSpace(ref))
+	data.Set("version", c.version.String())
+	data.Set("vnum", yesno(c.verseNumbers))
+	data.Set("fnote", yesno(c.footnotes))
+	data.Set("heading", yesno(c.headings))
+	data.Set("show_ref", yesno(c.showRef))
+	data.Set("show_adj", yesno(c.showAdjacent))
+	data.Set("omithidden", yesno(!c.hiddenText))
+	return data
+}
+
+// Get fetches a passage from bible.oremus.org, parses the result and returns the text formatted by the Client's Renderer, simple HTML by default
+func (c *Client) Get(ctx context.Context, ref string) (string, error) {
+	p, err := c.GetPassage(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	return c.renderer.Render(p), nil
+}
+
+// GetPassage fetches a passage from bible.oremus.org and returns the parsed structure
+// the context controls cancellation and deadlines for the whole request, including reading the body
+func (c *Client) GetPassage(ctx context.Context, ref string) (*Passage, error) {
+	ref, err := c.resolve(ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.validate(ref); err != nil {
+		return nil, err
+	}
+	if refs, err := ParseReferences(ref); err == nil && slices.ContainsFunc(refs, (*Reference).crossesBooks) {
+		return c.getCrossBook(ctx, ref, refs)
+	}
+
+	form := c.form(ref)
+	logger := c.logger.With("reference", form.Get("passage"))
+	key := cacheKey(form)
+	if c.cache != nil {
+		if body, ok := c.cache.Get(key); ok {
+			logger.DebugContext(ctx, "cache hit")
+			return c.passage(ref, body, logger)
+		}
+	}
+
+	body, err := c.fetchWithRetry(ctx, form, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := c.passage(ref, body, logger)
+	if err != nil {
+		return nil, err
+	}
+	if c.cache != nil {
+		// a failure to cache is not a failure to fetch
+		if err := c.cache.Set(key, body); err != nil {
+			logger.WarnContext(ctx, "unable to cache response", "error", err)
+		}
+	}
+	return p, nil
+}
+
+// fetchWithRetry calls fetch, honoring the rate limit and retrying transient failures
+func (c *Client) fetchWithRetry(ctx context.Context, form url.Values, logger *slog.Logger) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		body, err := c.fetch(ctx, form, logger)
+		if err == nil || attempt >= c.retries || !retryable(err) {
+			return body, err
+		}
+
+		delay, ok := c.backoff(attempt, err)
+		if !ok {
+			logger.InfoContext(ctx, "not retrying, Retry-After exceeds the backoff limit", "error", err)
+			return body, err
+		}
+		logger.InfoContext(ctx, "retrying request", "attempt", attempt+1, "delay", delay, "error", err)
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// fetch posts the form to oremus and returns the body of a successful response
+func (c *Client) fetch(ctx context.Context, form url.Values, logger *slog.Logger) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ctxErr(ctx, temporary(err))
+	}
+
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, ctxErr(ctx, temporary(err))
+	}
+	logger.DebugContext(ctx, "fetched passage", "status", resp.StatusCode, "latency", time.Since(start), "bytes", len(body))
+
+	if resp.StatusCode != http.StatusOK {
+		se := newHTTPStatusError(resp.StatusCode, body)
+		se.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		return nil, se
+	}
+	return body, nil
+}
+
+// passage parses a response body and fills in the metadata
+func (c *Client) passage(ref string, body []byte, logger *slog.Logger) (*Passage, error) {
+	p, err := parse(string(body), logger)
+	if err != nil {
+		return nil, err
+	}
+	p.Reference = strings.TrimSpace(ref)
+	p.Version = c.version
+	return p, nil
+}
+
+// cacheKey identifies a request by its normalized reference, version and form options
+func cacheKey(form url.Values) string {
+	key := maps.Clone(form)
+	if clean, err := CleanReference(form.Get("passage")); err == nil {
+		key.Set("passage", clean)
+	}
+	return key.Encode()
+}
+
+// validate rejects references to books the selected version does not have and to chapters or verses that do not exist
+// references we cannot parse are passed to oremus as-is, it may know better
+func (c *Client) validate(ref string) error {
+	refs, err := ParseReferences(ref)
+	if err != nil {
+		return nil
+	}
+	for _, r := range refs {
+		if err := r.ValidateVersion(c.version); err != nil {
+			return err
+		}
+		if err := r.ValidateExists(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ctxErr prefers the context's error over the transport's wrapped version so callers can compare against ctx.Err()
+func ctxErr(ctx context.Context, err error) error {
+	if cerr := ctx.Err(); cerr != nil {
+		return cerr
+	}
+	return err
+}
@@ -0,0 +1,223 @@
+package oremus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// minimalLection is the smallest response parse accepts
+const minimalLection = `<div class="bibletext"><p>In the beginning</p></div>`
+
+// stallServer returns a server whose handler blocks until the client goes away or the test ends
+// if flush is set, the headers are sent before stalling so the stall happens while reading the body
+func stallServer(t *testing.T, flush bool) *httptest.Server {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if flush {
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+		}
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	t.Cleanup(func() {
+		close(release)
+		ts.Close()
+	})
+	return ts
+}
+
+func TestGetDeadline(t *testing.T) {
+	ts := stallServer(t, false)
+	c := NewClient(WithBaseURL(ts.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.Get(ctx, "gen 1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if err != ctx.Err() {
+		t.Errorf("expected ctx.Err(), got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Get took %s to notice the deadline", elapsed)
+	}
+}
+
+func TestGetCancel(t *testing.T) {
+	ts := stallServer(t, false)
+	c := NewClient(WithBaseURL(ts.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := c.Get(ctx, "gen 1")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Get took %s to notice the cancellation", elapsed)
+	}
+}
+
+func TestGetStallInBody(t *testing.T) {
+	ts := stallServer(t, true)
+	c := NewClient(WithBaseURL(ts.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Get(ctx, "gen 1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestGetForm(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("bad form: %v", err)
+		}
+		if got := r.PostForm.Get("passage"); got != "Genesis 1:1" {
+			t.Errorf("passage: got %q", got)
+		}
+		if got := r.PostForm.Get("vnum"); got != "yes" {
+			t.Errorf("vnum: got %q", got)
+		}
+		if got := r.PostForm.Get("omithidden"); got != "yes" {
+			t.Errorf("omithidden: got %q", got)
+		}
+		w.Write([]byte(minimalLection))
+	}))
+	defer ts.Close()
+
+	c := NewClient(WithBaseURL(ts.URL), WithVerseNumbers(true))
+	if _, err := c.Get(context.Background(), "  Genesis 1:1\t"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetHTTPStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "internal   server\nerror", http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+	c := NewClient(WithBaseURL(ts.URL))
+
+	_, err := c.Get(context.Background(), "gen 1")
+	var se *HTTPStatusError
+	if !errors.As(err, &se) {
+		t.Fatalf("expected *HTTPStatusError, got %v", err)
+	}
+	if se.StatusCode != http.StatusInternalServerError {
+		t.Errorf("wrong status %d", se.StatusCode)
+	}
+	if se.Body != "internal server error" {
+		t.Errorf("wrong body %q", se.Body)
+	}
+}
+
+func TestGetNotFound(t *testing.T) {
+	ts := fixtureServer(t, "not-found.html")
+	c := NewClient(WithBaseURL(ts.URL))
+
+	s, err := c.Get(context.Background(), "Hezekiah 4:1")
+	if !errors.Is(err, ErrPassageNotFound) {
+		t.Fatalf("expected ErrPassageNotFound, got %v", err)
+	}
+	if s != "" {
+		t.Errorf("expected no text, got %q", s)
+	}
+}
+
+func TestLogger(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("passage") == "Exodus 2" {
+			http.Error(w, "no", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`<div class="bibletext"><p>In the <blink>beginning</blink></p></div>`))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c := NewClient(WithBaseURL(ts.URL), WithLogger(logger))
+
+	if _, err := c.Get(context.Background(), "Genesis 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var sawFetch, sawTag bool
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var rec map[string]any
+		if err := dec.Decode(&rec); err != nil {
+			t.Fatalf("bad log record: %v", err)
+		}
+		if rec["reference"] != "Genesis 1" {
+			t.Errorf("record without reference: %v", rec)
+		}
+		switch rec["msg"] {
+		case "fetched passage":
+			sawFetch = rec["status"] == float64(200) && rec["latency"] != nil
+		case "unprocessed tag":
+			sawTag = rec["tag"] == "blink" && rec["depth"] != nil
+		}
+	}
+	if !sawFetch || !sawTag {
+		t.Errorf("missing records, fetch %v tag %v", sawFetch, sawTag)
+	}
+
+	// returned errors are not also logged
+	buf.Reset()
+	if _, err := c.Get(context.Background(), "Exodus 2"); err == nil {
+		t.Fatalf("expected error")
+	}
+	if strings.Contains(buf.String(), `"level":"ERROR"`) || strings.Contains(buf.String(), `"level":"WARN"`) {
+		t.Errorf("returned error was logged: %s", buf.String())
+	}
+}
+
+func TestClientForm(t *testing.T) {
+	all := []Option{WithVerseNumbers(true), WithFootnotes(true), WithHeadings(true), WithShowReference(true), WithShowAdjacent(true), WithHiddenText(true)}
+	tests := []struct {
+		opts []Option
+		want map[string]string
+	}{
+		{nil, map[string]string{"vnum": "no", "fnote": "no", "heading": "no", "show_ref": "no", "show_adj": "no", "omithidden": "yes"}},
+		{all, map[string]string{"vnum": "yes", "fnote": "yes", "heading": "yes", "show_ref": "yes", "show_adj": "yes", "omithidden": "no"}},
+		{[]Option{WithVerseNumbers(true), WithVerseNumbers(false)}, map[string]string{"vnum": "no"}},
+		{[]Option{WithFootnotes(true)}, map[string]string{"vnum": "no", "fnote": "yes", "heading": "no"}},
+	}
+	for i, tc := range tests {
+		form := NewClient(tc.opts...).form("  Genesis 1:1\t")
+		if got := form.Get("passage"); got != "Genesis 1:1" {
+			t.Errorf("%d: passage: got %q", i, got)
+		}
+		for key, want := range tc.want {
+			if got := form.Get(key); got != want {
+				t.Errorf("%d: %s: got %q, want %q", i, key, got, want)
+			}
+		}
+	}
+
+	c := NewClient(WithHTTPClient(nil), WithBaseURL(""))
+	if c.httpClient == nil || c.baseURL != DefaultBaseURL {
+		t.Errorf("empty options replaced the defaults: %v %q", c.httpClient, c.baseURL)
+	}
+}
@@ -0,0 +1,250 @@
+package oremus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// crossesBooks reports whether the reference ends in a different book, "Genesis 50:22-Exodus 2:10"
+// such a reference has a single ChapterVerseRange whose end is in EndBook
+func (r *Reference) crossesBooks() bool {
+	return r.EndBook != ""
+}
+
+// endBookName returns the book the reference ends in, with its prefix
+func (r *Reference) endBookName() string {
+	if !r.crossesBooks() {
+		return r.bookName()
+	}
+	end := Reference{Book: r.EndBook, Prefix: r.EndPrefix}
+	return end.bookName()
+}
+
+// endpoints returns the first and last chapter:verse of a cross-book reference as references to single points
+func (r *Reference) endpoints() (start, end *Reference) {
+	cv := r.ChapterVerseRange[0]
+	start = &Reference{Book: r.Book, Prefix: r.Prefix, ChapterVerseRange: []ChapterVerseRange{{
+		StartChapter: cv.StartChapter, EndChapter: cv.StartChapter,
+		StartVerse: cv.StartVerse, EndVerse: cv.StartVerse,
+		StartVerseSuffix: cv.StartVerseSuffix,
+	}}}
+	end = &Reference{Book: r.EndBook, Prefix: r.EndPrefix, ChapterVerseRange: []ChapterVerseRange{{
+		StartChapter: cv.EndChapter, EndChapter: cv.EndChapter,
+		StartVerse: cv.EndVerse, EndVerse: cv.EndVerse,
+		StartVerseSuffix: cv.EndVerseSuffix,
+	}}}
+	return start, end
+}
+
+// writePoint writes a single chapter or chapter:verse with its suffix
+func writePoint(buf *strings.Builder, chapter, verse int, suffix rune) {
+	buf.WriteString(strconv.Itoa(chapter))
+	if verse != 0 {
+		buf.WriteRune(':')
+		buf.WriteString(strconv.Itoa(verse))
+		if suffix == 'a' || suffix == 'b' {
+			buf.WriteRune(suffix)
+		}
+	}
+}
+
+// crossBookString formats a cross-book reference, "Genesis 50:22-Exodus 2:10"
+func (r *Reference) crossBookString(book, endBook string) string {
+	cv := r.ChapterVerseRange[0]
+	var buf strings.Builder
+	buf.WriteString(book)
+	buf.WriteRune(' ')
+	writePoint(&buf, cv.StartChapter, cv.StartVerse, cv.StartVerseSuffix)
+	buf.WriteRune('-')
+	buf.WriteString(endBook)
+	buf.WriteRune(' ')
+	writePoint(&buf, cv.EndChapter, cv.EndVerse, cv.EndVerseSuffix)
+	return buf.String()
+}
+
+// splitCrossBook looks for a dash followed by a book name, returning the text either side of it
+func splitCrossBook(in string, loc Locale) (start, end string, ok bool) {
+	for i, r := range in {
+		if r != '-' && r != '–' && r != '—' {
+			continue
+		}
+		after := in[i+utf8.RuneLen(r):]
+		if _, _, _, err := loc.parseBook(strings.Fields(after)); err == nil {
+			return in[:i], after, true
+		}
+	}
+	return "", "", false
+}
+
+// endPoint matches a range that ends with "end" in place of a verse, Exodus 2:end
+var endPoint = regexp.MustCompile(`(?i):\s*end(\s+of(\s+the)?\s+chapter)?\s*$`)
+
+// parseCrossBook parses the two ends of a range such as "Genesis 50:22 - Exodus 2:10"
+func parseCrossBook(startIn, endIn string, prev *Reference, n Notation, loc Locale) (*Reference, error) {
+	start, err := parseReference(startIn, prev, n, loc)
+	if err != nil {
+		return nil, err
+	}
+	// "Exodus 2:end" is the last verse of chapter 2, read it as 2:1-end and keep the end
+	toEnd := endPoint.MatchString(endIn)
+	if toEnd {
+		endIn = endPoint.ReplaceAllString(endIn, ":1-end")
+	}
+	end, err := parseReference(endIn, nil, n, loc)
+	if err != nil {
+		return nil, err
+	}
+	if toEnd && len(end.ChapterVerseRange) == 1 {
+		end.ChapterVerseRange[0].StartVerse = end.ChapterVerseRange[0].EndVerse
+	}
+	if !isPoint(start) || !isPoint(end) {
+		return nil, errors.New("a range across books must run from one chapter or verse to another")
+	}
+
+	s, e := start.ChapterVerseRange[0], end.ChapterVerseRange[0]
+	out := &Reference{Book: start.Book, Prefix: start.Prefix, ChapterVerseRange: []ChapterVerseRange{{
+		StartChapter: s.StartChapter, StartVerse: s.StartVerse, StartVerseSuffix: s.StartVerseSuffix,
+		EndChapter: e.StartChapter, EndVerse: e.StartVerse, EndVerseSuffix: e.StartVerseSuffix,
+	}}}
+
+	startCode, _ := start.BookCode()
+	endCode, _ := end.BookCode()
+	if startCode == endCode {
+		// Genesis 1:1 - Genesis 2:4 is an ordinary range
+		if out.ChapterVerseRange[0].EndVerse == 0 && out.ChapterVerseRange[0].StartVerse != 0 {
+			return nil, errors.New("invalid verse range")
+		}
+		return out, out.ChapterVerseRange[0].Validate()
+	}
+
+	out.EndBook, out.EndPrefix = end.Book, end.Prefix
+	if start.Ordinal(Protestant) >= end.Ordinal(Protestant) {
+		return nil, fmt.Errorf("%s comes after %s", start.bookName(), end.bookName())
+	}
+	return out, nil
+}
+
+// isPoint reports whether the reference is a single chapter or verse
+func isPoint(r *Reference) bool {
+	if len(r.ChapterVerseRange) != 1 {
+		return false
+	}
+	cv := r.ChapterVerseRange[0]
+	return cv.StartChapter != 0 && cv.StartChapter == cv.EndChapter && cv.StartVerse == cv.EndVerse && cv.EndVerseSuffix == unset && cv.StartVerseSuffix != 'f'
+}
+
+// Split returns one reference per book covered by the reference
+// books between the first and last are whole, in the Protestant order; the Apocrypha, and Psalm 151, are only included when the range starts or ends in them
+func (r *Reference) Split() ([]*Reference, error) {
+	if !r.crossesBooks() {
+		return []*Reference{r}, nil
+	}
+	startRef, endRef := r.endpoints()
+	if err := startRef.ValidateExists(); err != nil {
+		return nil, err
+	}
+	if err := endRef.ValidateExists(); err != nil {
+		return nil, err
+	}
+	cv := r.ChapterVerseRange[0]
+
+	var out []*Reference
+
+	// the first book, from the start to the end of the book
+	startCode, _ := r.BookCode()
+	first := &Reference{Book: r.Book, Prefix: r.Prefix}
+	chapters, _ := first.chapters()
+	firstChapter, lastChapter := first.chapterRange()
+	wholeBook := lastChapter
+	if startCode == "PSA" && cv.StartChapter < psalm151 {
+		lastChapter = psalm151 - 1
+	}
+	if cv.StartChapter != firstChapter || cv.StartVerse > 1 || cv.StartVerseSuffix == 'b' || lastChapter != wholeBook {
+		tail := ChapterVerseRange{StartChapter: cv.StartChapter, EndChapter: lastChapter}
+		if cv.StartVerse > 1 || cv.StartVerseSuffix == 'b' {
+			tail.StartVerse, tail.StartVerseSuffix = cv.StartVerse, cv.StartVerseSuffix
+			tail.EndVerse = chapters[lastChapter-firstChapter]
+		}
+		first.ChapterVerseRange = []ChapterVerseRange{tail}
+	}
+	out = append(out, first)
+
+	// whole books in between
+	endCode, _ := endRef.BookCode()
+	apocryphal := apocrypha[r.Book] || apocrypha[r.EndBook]
+	order := canons[Protestant]
+	for _, code := range order[ordinals[Protestant][startCode] : ordinals[Protestant][endCode]-1] {
+		book := bookCodeLookup[code]
+		if apocrypha[book.Book] && !apocryphal {
+			continue
+		}
+		whole := &Reference{Book: book.Book, Prefix: book.Prefix}
+		if code == "PSA" && !apocryphal {
+			whole.ChapterVerseRange = []ChapterVerseRange{{StartChapter: 1, EndChapter: psalm151 - 1}}
+		}
+		out = append(out, whole)
+	}
+
+	// the last book, from the start of the book to the end
+	last := &Reference{Book: r.EndBook, Prefix: r.EndPrefix}
+	chapters, _ = last.chapters()
+	firstChapter, lastChapter = last.chapterRange()
+	if cv.EndChapter != lastChapter || (cv.EndVerse != 0 && cv.EndVerse < chapters[lastChapter-firstChapter]) || cv.EndVerseSuffix == 'a' {
+		head := ChapterVerseRange{StartChapter: firstChapter, EndChapter: cv.EndChapter, EndVerse: cv.EndVerse, EndVerseSuffix: cv.EndVerseSuffix}
+		if cv.EndVerse != 0 {
+			head.StartVerse = 1
+		}
+		last.ChapterVerseRange = []ChapterVerseRange{head}
+	}
+	out = append(out, last)
+	return out, nil
+}
+
+// splitReferences expands any cross-book references into one reference per book
+func splitReferences(refs []*Reference) ([]*Reference, error) {
+	var out []*Reference
+	for _, r := range refs {
+		parts, err := r.Split()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, parts...)
+	}
+	return out, nil
+}
+
+// maxCrossBooks is the most books getCrossBook will fetch for one reference, each book is a request to oremus
+const maxCrossBooks = 3
+
+// getCrossBook fetches a reference that spans books one book at a time, oremus only serves a passage from a single book
+func (c *Client) getCrossBook(ctx context.Context, ref string, refs []*Reference) (*Passage, error) {
+	var parts []*Reference
+	for _, r := range refs {
+		split, err := r.Split()
+		if err != nil {
+			return nil, err
+		}
+		if len(split) > maxCrossBooks {
+			return nil, fmt.Errorf("%s spans %d books, at most %d are fetched for one reference", r, len(split), maxCrossBooks)
+		}
+		parts = append(parts, split...)
+	}
+	pieces := make([]string, len(parts))
+	for i, r := range parts {
+		pieces[i] = r.String()
+	}
+
+	p := &Passage{Reference: strings.TrimSpace(ref), Version: c.version}
+	for _, res := range c.GetMany(ctx, pieces) {
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		p.Paragraphs = append(p.Paragraphs, res.Passage.Paragraphs...)
+	}
+	return p, nil
+}
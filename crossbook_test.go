@@ -0,0 +1,260 @@
+package oremus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestParseCrossBook(t *testing.T) {
+	tests := map[string]string{
+		"Genesis 50:22 - Exodus 2:10":     "Genesis 50:22-Exodus 2:10",
+		"gen 50:22-ex 2:10":               "Genesis 50:22-Exodus 2:10",
+		"gen 50–ex 2":                     "Genesis 50-Exodus 2",
+		"gen 50:22b - ex 2:10a":           "Genesis 50:22b-Exodus 2:10a",
+		"1 sam 31:1 - 2 sam 1:27":         "1 Samuel 31:1-2 Samuel 1:27",
+		"obad 21 - jonah 1:3":             "Obadiah 1:21-Jonah 1:3",
+		"GEN 50:22-EXO 2:10":              "Genesis 50:22-Exodus 2:10",
+		"mal 4:5-matt 1:1":                "Malachi 4:5-Matthew 1:1",
+		"gen 1:1 - gen 2:4":               "Genesis 1:1-2:4",
+		"gen 50:22-ex 2:10; 3:1":          "Genesis 50:22-Exodus 2:10; 3:1",
+		"ps 23; 150:6 - prov 1:7":         "Psalms 23; Psalms 150:6-Proverbs 1:7",
+		"gen 50:22 - ex 2:end":            "Genesis 50:22-Exodus 2:25",
+		"gen 50:22 - ex 2:end of chapter": "Genesis 50:22-Exodus 2:25",
+	}
+	for in, want := range tests {
+		got, err := CleanReference(in)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: got %q, want %q", in, got, want)
+		}
+		again, err := CleanReference(got)
+		if err != nil || again != got {
+			t.Errorf("%s: did not round-trip, %q %v", in, again, err)
+		}
+	}
+
+	r, err := ParseReference("gen 50:22 - ex 2:10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Book != "Genesis" || r.EndBook != "Exodus" || len(r.ChapterVerseRange) != 1 {
+		t.Errorf("unexpected reference %+v", r)
+	}
+
+	bad := []string{
+		"ex 2:10 - gen 50:22",
+		"gen 50:22-ex",
+		"gen 50:20-22 - ex 2:10",
+		"gen 50:22 - ex 2:1-10",
+		"gen 50:22 - ex 2:10,12",
+		"gen 2:4 - gen 1:1",
+		"gen 50:22 - ex 2:e",
+		"gen 50:22 - ex end",
+	}
+	for _, in := range bad {
+		if s, err := CleanReference(in); err == nil {
+			t.Errorf("%s: expected error, got %q", in, s)
+		}
+	}
+}
+
+func TestCrossBookFormats(t *testing.T) {
+	r, err := ParseReference("1 sam 31:8b - 2 sam 1:10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s, err := r.OSIS(); err != nil || s != "1Sam.31.8!b-2Sam.1.10" {
+		t.Errorf("OSIS: got %q %v", s, err)
+	}
+	if s, err := r.USFM(); err != nil || s != "1SA 31:8b-2SA 1:10" {
+		t.Errorf("USFM: got %q %v", s, err)
+	}
+}
+
+func TestCrossBookValidate(t *testing.T) {
+	tests := map[string]bool{
+		"gen 50:22 - ex 2:10": true,
+		"gen 50:27 - ex 2:10": false,
+		"gen 50:22 - ex 41":   false,
+		"mal 4:5 - tobit 1:3": true,
+	}
+	for in, ok := range tests {
+		r, err := ParseReference(in)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", in, err)
+		}
+		if err := r.ValidateExists(); (err == nil) != ok {
+			t.Errorf("%s: unexpected result %v", in, err)
+		}
+	}
+
+	r, _ := ParseReference("mal 4:5 - tobit 1:3")
+	if err := r.ValidateVersion(AV); err == nil {
+		t.Errorf("expected Tobit to be missing from the AV")
+	}
+	r, _ = ParseReference("ps 150 - prov 1")
+	if err := r.ValidateVersion(CWPsalter); err == nil {
+		t.Errorf("expected Proverbs to be missing from a psalter")
+	}
+	if err := r.ValidateVersion(AV); err != nil {
+		t.Errorf("Psalm 150 to Proverbs 1 should be in the AV: %v", err)
+	}
+	r, _ = ParseReference("ps 151 - prov 1")
+	if err := r.ValidateVersion(AV); err == nil {
+		t.Errorf("expected Psalm 151 to be missing from the AV")
+	}
+}
+
+func TestGetCrossBookPsalms(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.FormValue("passage"))
+		mu.Unlock()
+		w.Write([]byte(minimalLection))
+	}))
+	defer ts.Close()
+
+	c := NewClient(WithBaseURL(ts.URL), WithVersion(AV))
+	if _, err := c.GetPassage(context.Background(), "Ps 150 - Prov 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	slices.Sort(requested)
+	if want := []string{"Proverbs 1", "Psalms 150"}; !slices.Equal(requested, want) {
+		t.Errorf("requested %q, want %q", requested, want)
+	}
+
+	r, _ := ParseReference("ps 150 - prov 1:1")
+	n := 0
+	for range r.Verses() {
+		n++
+	}
+	if n != 7 {
+		t.Errorf("expected Psalm 150 and Proverbs 1:1 to be 7 verses, got %d", n)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := map[string][]string{
+		"gen 50:22 - ex 2:10":     {"Genesis 50:22-26", "Exodus 1:1-2:10"},
+		"gen 50 - ex 2":           {"Genesis 50", "Exodus 1-2"},
+		"gen 1:1 - ex 40:38":      {"Genesis", "Exodus"},
+		"gen 50:26 - lev 1:1":     {"Genesis 50:26", "Exodus", "Leviticus 1:1"},
+		"mal 4:6 - matt 1:1":      {"Malachi 4:6", "Matthew 1:1"},
+		"mal 4:6 - tob 1:1":       {"Malachi 4:6", "Tobit 1:1"},
+		"2 macc 15:39 - matt 1:1": {"2 Maccabees 15:39", "1 Esdras", "Prayer of Manasseh", "3 Maccabees", "2 Esdras", "4 Maccabees", "Matthew 1:1"},
+		"gen 1:1":                 {"Genesis 1:1"},
+		"ps 150 - prov 1:7":       {"Psalms 150", "Proverbs 1:1-7"},
+		"ps 150:6 - prov 1:7":     {"Psalms 150:6", "Proverbs 1:1-7"},
+		"ps 1:1 - prov 1:7":       {"Psalms 1-150", "Proverbs 1:1-7"},
+		"job 42 - prov 1":         {"Job 42", "Psalms 1-150", "Proverbs 1"},
+		"ps 151 - prov 1":         {"Psalms 151", "Proverbs 1"},
+		"job 42 - ps 151":         {"Job 42", "Psalms"},
+		"gen 50:1 - ex 1:1":       {"Genesis 50", "Exodus 1:1"},
+	}
+	for in, want := range tests {
+		r, err := ParseReference(in)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", in, err)
+		}
+		parts, err := r.Split()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", in, err)
+			continue
+		}
+		var got []string
+		for _, p := range parts {
+			got = append(got, p.String())
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: got %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCrossBookVerses(t *testing.T) {
+	r, err := ParseReference("gen 50:25 - ex 1:2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for v := range r.Verses() {
+		got = append(got, v.String())
+	}
+	want := []string{"Genesis 50:25", "Genesis 50:26", "Exodus 1:1", "Exodus 1:2"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	set := mustVerseSet(t, "gen 50:22 - ex 2:10")
+	if got := set.String(); got != "Genesis 50:22-26; Exodus 1:1-2:10" {
+		t.Errorf("verse set: got %q", got)
+	}
+	if !set.Contains(mustVerseSet(t, "ex 1")) {
+		t.Errorf("expected the verse set to contain Exodus 1")
+	}
+}
+
+func TestGetCrossBook(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.FormValue("passage"))
+		mu.Unlock()
+		w.Write([]byte(`<div class="bibletext"><p>` + r.FormValue("passage") + `</p></div>`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(WithBaseURL(ts.URL))
+	p, err := c.GetPassage(context.Background(), "gen 50:22 - ex 2:10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	slices.Sort(requested)
+	if want := []string{"Exodus 1:1-2:10", "Genesis 50:22-26"}; !slices.Equal(requested, want) {
+		t.Errorf("requested %q, want %q", requested, want)
+	}
+	if p.Reference != "gen 50:22 - ex 2:10" {
+		t.Errorf("wrong reference %q", p.Reference)
+	}
+	if len(p.Paragraphs) != 2 || p.Paragraphs[0].Lines[0].Runs[0].Text != "Genesis 50:22-26" || p.Paragraphs[1].Lines[0].Runs[0].Text != "Exodus 1:1-2:10" {
+		t.Errorf("passages not joined in order: %+v", p.Paragraphs)
+	}
+}
+
+func TestGetCrossBookLimit(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.FormValue("passage"))
+		mu.Unlock()
+		w.Write([]byte(minimalLection))
+	}))
+	defer ts.Close()
+
+	c := NewClient(WithBaseURL(ts.URL))
+	if _, err := c.GetPassage(context.Background(), "gen 1:1 - rev 22:21"); err == nil {
+		t.Errorf("expected an error for a range across the whole Bible")
+	}
+	if len(requested) != 0 {
+		t.Errorf("requested %q before giving up", requested)
+	}
+
+	if _, err := c.GetPassage(context.Background(), "gen 50:26 - lev 1:1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requested) != 3 {
+		t.Errorf("expected 3 requests, got %q", requested)
+	}
+}
@@ -0,0 +1,40 @@
+package oremus
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrPassageNotFound is returned when oremus answers with its error page instead of a passage
+var ErrPassageNotFound = errors.New("passage not found")
+
+// ErrNoLection is returned when the response contains no class="bibletext" block, usually because the oremus markup changed
+var ErrNoLection = errors.New("no lection in response")
+
+// maxErrorBody is how much of an unsuccessful response body is kept in an HTTPStatusError
+const maxErrorBody = 512
+
+// HTTPStatusError is returned when oremus answers with a non-200 status
+type HTTPStatusError struct {
+	StatusCode int
+	Body       string        // the start of the response body
+	RetryAfter time.Duration // from the Retry-After header, if any
+}
+
+func (e *HTTPStatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("oremus returned HTTP %d", e.StatusCode)
+	}
+	return fmt.Sprintf("oremus returned HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// newHTTPStatusError builds an HTTPStatusError with a trimmed snippet of the body
+func newHTTPStatusError(status int, body []byte) *HTTPStatusError {
+	if len(body) > maxErrorBody {
+		body = body[:maxErrorBody]
+	}
+	snippet := strings.ToValidUTF8(string(body), "")
+	return &HTTPStatusError{StatusCode: status, Body: strings.TrimSpace(collapseSpace(snippet))}
+}
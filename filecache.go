@@ -0,0 +1,74 @@
+package oremus
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileCache is a Cache storing one file per response in a directory
+// writes are atomic, a reader never sees a partially written response
+type FileCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// NewFileCache returns a FileCache in dir, creating it if needed
+// entries older than ttl are ignored, a ttl of 0 keeps them forever
+func NewFileCache(dir string, ttl time.Duration) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir, ttl: ttl}, nil
+}
+
+// path returns the file used for key, keys are hashed since they contain characters unsafe in file names
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".html")
+}
+
+// Get returns the cached response for key
+func (c *FileCache) Get(key string) ([]byte, bool) {
+	p := c.path(key)
+	if c.ttl > 0 {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return nil, false
+		}
+		if time.Since(fi.ModTime()) > c.ttl {
+			// expired, clean up so the directory does not grow forever
+			os.Remove(p)
+			return nil, false
+		}
+	}
+
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
+
+// Set stores the response for key by writing a temporary file and renaming it into place
+func (c *FileCache) Set(key string, value []byte) error {
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(value)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		return errors.Join(err, os.Remove(tmp.Name()))
+	}
+	return nil
+}
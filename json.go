@@ -0,0 +1,123 @@
+package oremus
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// VerseText is the text of a single verse
+type VerseText struct {
+	Chapter int    `json:"chapter"`
+	Verse   int    `json:"verse"`
+	Text    string `json:"text"`
+}
+
+// passageJSON is the JSON form of a Passage
+type passageJSON struct {
+	Reference string      `json:"reference"`
+	Version   Version     `json:"version"`
+	Verses    []VerseText `json:"verses"`
+}
+
+// Verses splits the passage into verses using the verse numbers and chapter breaks in the text
+// the passage must be fetched WithVerseNumbers, otherwise everything is attributed to the first verse of the reference
+// the divine name is written as LORD, line and paragraph breaks become spaces
+func (p *Passage) Verses() []VerseText {
+	chapter, verse := 1, 1
+	if refs, err := ParseReferences(p.Reference); err == nil && refs[0].ChapterVerseRange[0].StartChapter != 0 {
+		cv := refs[0].ChapterVerseRange[0]
+		chapter = cv.StartChapter
+		verse = max(cv.StartVerse, 1)
+	}
+
+	var out []VerseText
+	var buf strings.Builder
+	flush := func() {
+		if txt := strings.TrimSpace(collapseSpace(buf.String())); txt != "" {
+			out = append(out, VerseText{Chapter: chapter, Verse: verse, Text: txt})
+		}
+		buf.Reset()
+	}
+
+	for _, para := range p.Paragraphs {
+		for _, line := range para.Lines {
+			for _, r := range line.Runs {
+				switch r.Kind {
+				case ChapterNumberRun:
+					flush()
+					chapter, verse = r.Number, 1
+				case VerseNumberRun:
+					flush()
+					verse = r.Number
+				case DivineNameRun:
+					buf.WriteString(strings.ToUpper(r.Text))
+				default:
+					buf.WriteString(r.Text)
+				}
+			}
+			buf.WriteString(" ")
+		}
+	}
+	flush()
+	return out
+}
+
+// MarshalJSON implements json.Marshaler, writing the reference, version and verses
+func (p *Passage) MarshalJSON() ([]byte, error) {
+	verses := p.Verses()
+	if verses == nil {
+		verses = []VerseText{}
+	}
+	return json.Marshal(passageJSON{
+		Reference: p.Reference,
+		Version:   p.Version,
+		Verses:    verses,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+// the passage is rebuilt as a single paragraph of numbered verses, formatting is not preserved
+func (p *Passage) UnmarshalJSON(b []byte) error {
+	var pj passageJSON
+	if err := json.Unmarshal(b, &pj); err != nil {
+		return err
+	}
+
+	var line Line
+	chapter := 0
+	for _, v := range pj.Verses {
+		if v.Chapter != chapter {
+			chapter = v.Chapter
+			line.Runs = append(line.Runs, Run{Kind: ChapterNumberRun, Text: strconv.Itoa(v.Chapter), Number: v.Chapter})
+		}
+		if v.Verse != 1 || len(line.Runs) == 0 || line.Runs[len(line.Runs)-1].Kind != ChapterNumberRun {
+			line.Runs = append(line.Runs, Run{Kind: VerseNumberRun, Text: strconv.Itoa(v.Verse), Number: v.Verse})
+		}
+		line.Runs = append(line.Runs, Run{Kind: TextRun, Text: v.Text + " "})
+	}
+
+	*p = Passage{Reference: pj.Reference, Version: pj.Version}
+	if len(line.Runs) > 0 {
+		p.Paragraphs = []Paragraph{{Lines: []Line{line}}}
+	}
+	return nil
+}
+
+// GetJSON fetches a passage using the default Client and returns it as JSON
+func GetJSON(ctx context.Context, ref string) ([]byte, error) {
+	return defaultClient.GetJSON(ctx, ref)
+}
+
+// GetJSON fetches a passage and returns it as JSON split into verses
+// verse numbers are always requested, regardless of WithVerseNumbers, since they are needed to find the verse boundaries
+func (c *Client) GetJSON(ctx context.Context, ref string) ([]byte, error) {
+	vc := *c
+	vc.verseNumbers = true
+	p, err := vc.GetPassage(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(p)
+}
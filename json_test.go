@@ -0,0 +1,113 @@
+package oremus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVerses(t *testing.T) {
+	p := mustParse(t, fixture(t, "genesis-1-29-2-3-vnum.html"))
+	p.Reference = "Genesis 1:29-2:3"
+
+	verses := p.Verses()
+	want := []struct{ chapter, verse int }{{1, 29}, {1, 30}, {1, 31}, {2, 1}, {2, 2}, {2, 3}}
+	if len(verses) != len(want) {
+		t.Fatalf("expected %d verses, got %+v", len(want), verses)
+	}
+	for i, v := range verses {
+		if v.Chapter != want[i].chapter || v.Verse != want[i].verse {
+			t.Errorf("verse %d: got %d:%d, want %d:%d", i, v.Chapter, v.Verse, want[i].chapter, want[i].verse)
+		}
+	}
+	if verses[3].Text != "Thus the heavens and the earth were finished, and all their multitude." {
+		t.Errorf("wrong text for 2:1: %q", verses[3].Text)
+	}
+	if !strings.HasSuffix(verses[1].Text, "for food.” And it was so.") {
+		t.Errorf("wrong text for 1:30: %q", verses[1].Text)
+	}
+}
+
+func TestVersesDivineName(t *testing.T) {
+	in := `<div class="bibletext"><p><sup class="ii">1</sup>The <span>Lord</span> is my shepherd,<br />I shall not want.</p><p><sup class="ii">2</sup>He makes me</p></div>`
+	p := mustParse(t, in)
+	p.Reference = "Psalm 23"
+
+	verses := p.Verses()
+	if len(verses) != 2 {
+		t.Fatalf("expected 2 verses, got %+v", verses)
+	}
+	if verses[0] != (VerseText{Chapter: 23, Verse: 1, Text: "The LORD is my shepherd, I shall not want."}) {
+		t.Errorf("unexpected first verse %+v", verses[0])
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	p := mustParse(t, fixture(t, "genesis-1-29-2-3-vnum.html"))
+	p.Reference = "Genesis 1:29-2:3"
+	p.Version = NRSVAE
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var generic struct {
+		Reference string
+		Version   string
+		Verses    []map[string]any
+	}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if generic.Reference != "Genesis 1:29-2:3" || generic.Version != "NRSVAE" || len(generic.Verses) != 6 {
+		t.Errorf("unexpected JSON: %s", b)
+	}
+	if generic.Verses[0]["chapter"] != float64(1) || generic.Verses[0]["verse"] != float64(29) {
+		t.Errorf("unexpected first verse: %v", generic.Verses[0])
+	}
+
+	var back Passage
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b2, err := json.Marshal(&back)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(b, b2) {
+		t.Errorf("did not round-trip:\n%s\n%s", b, b2)
+	}
+}
+
+func TestGetJSON(t *testing.T) {
+	body := fixture(t, "genesis-1-29-2-3-vnum.html")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("vnum") != "yes" {
+			t.Errorf("GetJSON must request verse numbers")
+		}
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	c := NewClient(WithBaseURL(ts.URL))
+	b, err := c.GetJSON(context.Background(), "Genesis 1:29-2:3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var p Passage
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(p.Verses()) != 6 {
+		t.Errorf("expected 6 verses, got %s", b)
+	}
+	if c.verseNumbers {
+		t.Errorf("GetJSON must not change the Client")
+	}
+}
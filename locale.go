@@ -0,0 +1,443 @@
+package oremus
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Locale selects the language of book names when parsing and formatting references
+type Locale int
+
+const (
+	English Locale = iota
+	Spanish
+	German
+	French
+	// Latin uses the book names of the Nova Vulgata, chapters and verses still follow the NRSV
+	Latin
+)
+
+// String returns the name of the locale
+func (l Locale) String() string {
+	switch l {
+	case English:
+		return "English"
+	case Spanish:
+		return "Spanish"
+	case German:
+		return "German"
+	case French:
+		return "French"
+	case Latin:
+		return "Latin"
+	default:
+		return "Locale(" + strconv.Itoa(int(l)) + ")"
+	}
+}
+
+// localeBooks lists the name of each book in a locale followed by its abbreviations, English uses the books map
+// numbered books are written with a digit, "1 juan"; the parser reads "1.", "I" and "first" the same way
+var localeBooks = map[Locale]map[BookCode][]string{
+	Spanish: {
+		"GEN": {"Génesis", "gn", "gén"},
+		"EXO": {"Éxodo", "ex", "éx"},
+		"LEV": {"Levítico", "lv", "lev"},
+		"NUM": {"Números", "nm", "núm"},
+		"DEU": {"Deuteronomio", "dt", "deut"},
+		"JOS": {"Josué", "jos"},
+		"JDG": {"Jueces", "jue", "jc"},
+		"RUT": {"Rut", "rt"},
+		"1SA": {"1 Samuel", "1 sam", "1 s"},
+		"2SA": {"2 Samuel", "2 sam", "2 s"},
+		"1KI": {"1 Reyes", "1 re", "1 rey"},
+		"2KI": {"2 Reyes", "2 re", "2 rey"},
+		"1CH": {"1 Crónicas", "1 cr", "1 crón"},
+		"2CH": {"2 Crónicas", "2 cr", "2 crón"},
+		"EZR": {"Esdras", "esd"},
+		"NEH": {"Nehemías", "neh", "ne"},
+		"EST": {"Ester", "est"},
+		"JOB": {"Job", "jb"},
+		"PSA": {"Salmos", "salmo", "sal", "sl"},
+		"PRO": {"Proverbios", "prov", "pr"},
+		"ECC": {"Eclesiastés", "ecl", "qohélet", "qo"},
+		"SNG": {"Cantar de los Cantares", "cantares", "cant", "ct"},
+		"ISA": {"Isaías", "is"},
+		"JER": {"Jeremías", "jer", "jr"},
+		"LAM": {"Lamentaciones", "lam"},
+		"EZK": {"Ezequiel", "ez"},
+		"DAN": {"Daniel", "dn"},
+		"HOS": {"Oseas", "os"},
+		"JOL": {"Joel", "jl"},
+		"AMO": {"Amós", "am"},
+		"OBA": {"Abdías", "abd"},
+		"JON": {"Jonás", "jon"},
+		"MIC": {"Miqueas", "miq"},
+		"NAM": {"Nahúm", "nah"},
+		"HAB": {"Habacuc", "hab"},
+		"ZEP": {"Sofonías", "sof"},
+		"HAG": {"Hageo", "ag", "hag"},
+		"ZEC": {"Zacarías", "zac"},
+		"MAL": {"Malaquías", "mal"},
+		"MAT": {"Mateo", "mt"},
+		"MRK": {"Marcos", "mc", "mr"},
+		"LUK": {"Lucas", "lc"},
+		"JHN": {"Juan", "jn"},
+		"ACT": {"Hechos", "hechos de los apóstoles", "hch"},
+		"ROM": {"Romanos", "rom", "ro"},
+		"1CO": {"1 Corintios", "1 cor", "1 co"},
+		"2CO": {"2 Corintios", "2 cor", "2 co"},
+		"GAL": {"Gálatas", "gál"},
+		"EPH": {"Efesios", "ef"},
+		"PHP": {"Filipenses", "flp", "fil"},
+		"COL": {"Colosenses", "col"},
+		"1TH": {"1 Tesalonicenses", "1 tes"},
+		"2TH": {"2 Tesalonicenses", "2 tes"},
+		"1TI": {"1 Timoteo", "1 tim"},
+		"2TI": {"2 Timoteo", "2 tim"},
+		"TIT": {"Tito", "tit"},
+		"PHM": {"Filemón", "flm"},
+		"HEB": {"Hebreos", "heb"},
+		"JAS": {"Santiago", "sant", "stg"},
+		"1PE": {"1 Pedro", "1 pe"},
+		"2PE": {"2 Pedro", "2 pe"},
+		"1JN": {"1 Juan", "1 jn"},
+		"2JN": {"2 Juan", "2 jn"},
+		"3JN": {"3 Juan", "3 jn"},
+		"JUD": {"Judas", "jds"},
+		"REV": {"Apocalipsis", "apoc", "ap"},
+
+		"TOB": {"Tobías", "tob"},
+		"JDT": {"Judit", "jdt"},
+		"ESG": {"Ester griego", "adiciones a ester"},
+		"WIS": {"Sabiduría", "sab"},
+		"SIR": {"Eclesiástico", "sirácida", "eclo"},
+		"BAR": {"Baruc", "bar"},
+		"S3Y": {"Cántico de los tres jóvenes", "oración de azarías"},
+		"SUS": {"Susana", "sus"},
+		"BEL": {"Bel y el dragón", "bel"},
+		"1MA": {"1 Macabeos", "1 mac"},
+		"2MA": {"2 Macabeos", "2 mac"},
+		"3MA": {"3 Macabeos", "3 mac"},
+		"4MA": {"4 Macabeos", "4 mac"},
+		"1ES": {"3 Esdras", "3 esd"},
+		"2ES": {"4 Esdras", "4 esd"},
+		"MAN": {"Oración de Manasés", "man"},
+	},
+	German: {
+		"GEN": {"1 Mose", "1 mo", "1 mos", "genesis", "gen", "gn"},
+		"EXO": {"2 Mose", "2 mo", "2 mos", "exodus", "ex"},
+		"LEV": {"3 Mose", "3 mo", "3 mos", "levitikus", "lev"},
+		"NUM": {"4 Mose", "4 mo", "4 mos", "numeri", "num"},
+		"DEU": {"5 Mose", "5 mo", "5 mos", "deuteronomium", "dtn"},
+		"JOS": {"Josua", "jos"},
+		"JDG": {"Richter", "ri"},
+		"RUT": {"Rut", "ruth"},
+		"1SA": {"1 Samuel", "1 sam", "1 sm"},
+		"2SA": {"2 Samuel", "2 sam", "2 sm"},
+		"1KI": {"1 Könige", "1 kön", "1 kö"},
+		"2KI": {"2 Könige", "2 kön", "2 kö"},
+		"1CH": {"1 Chronik", "1 chr"},
+		"2CH": {"2 Chronik", "2 chr"},
+		"EZR": {"Esra", "esr"},
+		"NEH": {"Nehemia", "neh"},
+		"EST": {"Ester", "esther", "est"},
+		"JOB": {"Hiob", "ijob", "hi"},
+		"PSA": {"Psalmen", "psalm", "ps"},
+		"PRO": {"Sprüche", "sprichwörter", "spr"},
+		"ECC": {"Prediger", "kohelet", "pred", "koh"},
+		"SNG": {"Hoheslied", "hld"},
+		"ISA": {"Jesaja", "jes"},
+		"JER": {"Jeremia", "jer"},
+		"LAM": {"Klagelieder", "klgl"},
+		"EZK": {"Hesekiel", "ezechiel", "hes", "ez"},
+		"DAN": {"Daniel", "dan"},
+		"HOS": {"Hosea", "hos"},
+		"JOL": {"Joel"},
+		"AMO": {"Amos", "am"},
+		"OBA": {"Obadja", "obd"},
+		"JON": {"Jona", "jon"},
+		"MIC": {"Micha", "mi"},
+		"NAM": {"Nahum", "nah"},
+		"HAB": {"Habakuk", "hab"},
+		"ZEP": {"Zefanja", "zephanja", "zef"},
+		"HAG": {"Haggai", "hag"},
+		"ZEC": {"Sacharja", "sach"},
+		"MAL": {"Maleachi", "mal"},
+		"MAT": {"Matthäus", "matthaeus", "matth", "mt"},
+		"MRK": {"Markus", "mk"},
+		"LUK": {"Lukas", "lk"},
+		"JHN": {"Johannes", "joh"},
+		"ACT": {"Apostelgeschichte", "apg"},
+		"ROM": {"Römer", "röm"},
+		"1CO": {"1 Korinther", "1 kor"},
+		"2CO": {"2 Korinther", "2 kor"},
+		"GAL": {"Galater", "gal"},
+		"EPH": {"Epheser", "eph"},
+		"PHP": {"Philipper", "phil"},
+		"COL": {"Kolosser", "kol"},
+		"1TH": {"1 Thessalonicher", "1 thess"},
+		"2TH": {"2 Thessalonicher", "2 thess"},
+		"1TI": {"1 Timotheus", "1 tim"},
+		"2TI": {"2 Timotheus", "2 tim"},
+		"TIT": {"Titus", "tit"},
+		"PHM": {"Philemon", "phlm"},
+		"HEB": {"Hebräer", "hebr"},
+		"JAS": {"Jakobus", "jak"},
+		"1PE": {"1 Petrus", "1 petr"},
+		"2PE": {"2 Petrus", "2 petr"},
+		"1JN": {"1 Johannes", "1 joh"},
+		"2JN": {"2 Johannes", "2 joh"},
+		"3JN": {"3 Johannes", "3 joh"},
+		"JUD": {"Judas", "jud"},
+		"REV": {"Offenbarung", "offb"},
+
+		"TOB": {"Tobit", "tobias", "tob"},
+		"JDT": {"Judit", "judith", "jdt"},
+		"ESG": {"Zusätze zu Ester", "stücke zu ester"},
+		"WIS": {"Weisheit", "weish"},
+		"SIR": {"Jesus Sirach", "sirach", "sir"},
+		"BAR": {"Baruch", "bar"},
+		"S3Y": {"Gesang der drei Männer", "gebet asarjas"},
+		"SUS": {"Susanna", "sus"},
+		"BEL": {"Bel und der Drache", "bel"},
+		"1MA": {"1 Makkabäer", "1 makk"},
+		"2MA": {"2 Makkabäer", "2 makk"},
+		"3MA": {"3 Makkabäer", "3 makk"},
+		"4MA": {"4 Makkabäer", "4 makk"},
+		"1ES": {"3 Esra", "3 esr"},
+		"2ES": {"4 Esra", "4 esr"},
+		"MAN": {"Gebet des Manasse", "gebet manasses", "man"},
+	},
+	French: {
+		"GEN": {"Genèse", "gn"},
+		"EXO": {"Exode", "ex"},
+		"LEV": {"Lévitique", "lv"},
+		"NUM": {"Nombres", "nb"},
+		"DEU": {"Deutéronome", "dt"},
+		"JOS": {"Josué", "jos"},
+		"JDG": {"Juges", "jg"},
+		"RUT": {"Ruth", "rt"},
+		"1SA": {"1 Samuel", "1 sam", "1 s"},
+		"2SA": {"2 Samuel", "2 sam", "2 s"},
+		"1KI": {"1 Rois", "1 r"},
+		"2KI": {"2 Rois", "2 r"},
+		"1CH": {"1 Chroniques", "1 ch"},
+		"2CH": {"2 Chroniques", "2 ch"},
+		"EZR": {"Esdras", "esd"},
+		"NEH": {"Néhémie", "ne"},
+		"EST": {"Esther", "est"},
+		"JOB": {"Job", "jb"},
+		"PSA": {"Psaumes", "psaume", "ps"},
+		"PRO": {"Proverbes", "pr"},
+		"ECC": {"Ecclésiaste", "qohéleth", "qo"},
+		"SNG": {"Cantique des Cantiques", "cantique", "ct"},
+		"ISA": {"Ésaïe", "isaïe", "es", "is"},
+		"JER": {"Jérémie", "jr"},
+		"LAM": {"Lamentations", "lm"},
+		"EZK": {"Ézéchiel", "ez"},
+		"DAN": {"Daniel", "dn"},
+		"HOS": {"Osée", "os"},
+		"JOL": {"Joël", "jl"},
+		"AMO": {"Amos", "am"},
+		"OBA": {"Abdias", "ab"},
+		"JON": {"Jonas", "jon"},
+		"MIC": {"Michée", "mi"},
+		"NAM": {"Nahum", "na"},
+		"HAB": {"Habacuc", "ha"},
+		"ZEP": {"Sophonie", "so"},
+		"HAG": {"Aggée", "ag"},
+		"ZEC": {"Zacharie", "za"},
+		"MAL": {"Malachie", "ml"},
+		"MAT": {"Matthieu", "mt"},
+		"MRK": {"Marc", "mc"},
+		"LUK": {"Luc", "lc"},
+		"JHN": {"Jean", "jn"},
+		"ACT": {"Actes", "actes des apôtres", "ac"},
+		"ROM": {"Romains", "rm"},
+		"1CO": {"1 Corinthiens", "1 co"},
+		"2CO": {"2 Corinthiens", "2 co"},
+		"GAL": {"Galates", "ga"},
+		"EPH": {"Éphésiens", "ep"},
+		"PHP": {"Philippiens", "ph"},
+		"COL": {"Colossiens", "col"},
+		"1TH": {"1 Thessaloniciens", "1 th"},
+		"2TH": {"2 Thessaloniciens", "2 th"},
+		"1TI": {"1 Timothée", "1 tm"},
+		"2TI": {"2 Timothée", "2 tm"},
+		"TIT": {"Tite", "tt"},
+		"PHM": {"Philémon", "phm"},
+		"HEB": {"Hébreux", "he"},
+		"JAS": {"Jacques", "jc"},
+		"1PE": {"1 Pierre", "1 p"},
+		"2PE": {"2 Pierre", "2 p"},
+		"1JN": {"1 Jean", "1 jn"},
+		"2JN": {"2 Jean", "2 jn"},
+		"3JN": {"3 Jean", "3 jn"},
+		"JUD": {"Jude"},
+		"REV": {"Apocalypse", "ap"},
+
+		"TOB": {"Tobit", "tb"},
+		"JDT": {"Judith", "jdt"},
+		"ESG": {"Esther grec", "additions à esther"},
+		"WIS": {"Sagesse", "sg"},
+		"SIR": {"Siracide", "ecclésiastique", "si"},
+		"BAR": {"Baruch", "ba"},
+		"S3Y": {"Cantique des trois jeunes gens", "prière d'azarias"},
+		"SUS": {"Suzanne", "sus"},
+		"BEL": {"Bel et le dragon", "bel"},
+		"1MA": {"1 Maccabées", "1 m"},
+		"2MA": {"2 Maccabées", "2 m"},
+		"3MA": {"3 Maccabées", "3 m"},
+		"4MA": {"4 Maccabées", "4 m"},
+		"1ES": {"3 Esdras", "3 esd"},
+		"2ES": {"4 Esdras", "4 esd"},
+		"MAN": {"Prière de Manassé", "man"},
+	},
+	Latin: {
+		"GEN": {"Genesis", "gn"},
+		"EXO": {"Exodus", "ex"},
+		"LEV": {"Leviticus", "lv"},
+		"NUM": {"Numeri", "nm"},
+		"DEU": {"Deuteronomium", "dt"},
+		"JOS": {"Iosue", "ios"},
+		"JDG": {"Iudicum", "idc"},
+		"RUT": {"Ruth", "rt"},
+		"1SA": {"1 Samuelis", "1 sm"},
+		"2SA": {"2 Samuelis", "2 sm"},
+		"1KI": {"1 Regum", "1 reg"},
+		"2KI": {"2 Regum", "2 reg"},
+		"1CH": {"1 Paralipomenon", "1 par"},
+		"2CH": {"2 Paralipomenon", "2 par"},
+		"EZR": {"Esdrae", "esd"},
+		"NEH": {"Nehemiae", "neh"},
+		"EST": {"Esther", "est"},
+		"JOB": {"Iob", "ib"},
+		"PSA": {"Psalmi", "ps"},
+		"PRO": {"Proverbia", "prv"},
+		"ECC": {"Ecclesiastes", "qoheleth", "eccl", "qo"},
+		"SNG": {"Canticum Canticorum", "ct"},
+		"ISA": {"Isaias", "is"},
+		"JER": {"Ieremias", "ier"},
+		"LAM": {"Lamentationes", "lam"},
+		"EZK": {"Ezechiel", "ez"},
+		"DAN": {"Daniel", "dn"},
+		"HOS": {"Osee", "os"},
+		"JOL": {"Ioel", "il"},
+		"AMO": {"Amos", "am"},
+		"OBA": {"Abdias", "abd"},
+		"JON": {"Ionas", "ion"},
+		"MIC": {"Michaeas", "mi"},
+		"NAM": {"Nahum", "na"},
+		"HAB": {"Habacuc", "hab"},
+		"ZEP": {"Sophonias", "so"},
+		"HAG": {"Aggaeus", "ag"},
+		"ZEC": {"Zacharias", "za"},
+		"MAL": {"Malachias", "mal"},
+		"MAT": {"Matthaeus", "mt"},
+		"MRK": {"Marcus", "mc"},
+		"LUK": {"Lucas", "lc"},
+		"JHN": {"Ioannes", "iohannes", "ioh", "io"},
+		"ACT": {"Actus Apostolorum", "act"},
+		"ROM": {"Ad Romanos", "romanos", "rom"},
+		"1CO": {"1 ad Corinthios", "1 corinthios", "1 cor"},
+		"2CO": {"2 ad Corinthios", "2 corinthios", "2 cor"},
+		"GAL": {"Ad Galatas", "galatas", "gal"},
+		"EPH": {"Ad Ephesios", "ephesios", "eph"},
+		"PHP": {"Ad Philippenses", "philippenses", "phil"},
+		"COL": {"Ad Colossenses", "colossenses", "col"},
+		"1TH": {"1 ad Thessalonicenses", "1 thessalonicenses", "1 thess"},
+		"2TH": {"2 ad Thessalonicenses", "2 thessalonicenses", "2 thess"},
+		"1TI": {"1 ad Timotheum", "1 timotheum", "1 tim"},
+		"2TI": {"2 ad Timotheum", "2 timotheum", "2 tim"},
+		"TIT": {"Ad Titum", "titum", "tit"},
+		"PHM": {"Ad Philemonem", "philemonem", "phlm"},
+		"HEB": {"Ad Hebraeos", "hebraeos", "hebr"},
+		"JAS": {"Iacobi", "iac"},
+		"1PE": {"1 Petri", "1 petr"},
+		"2PE": {"2 Petri", "2 petr"},
+		"1JN": {"1 Ioannis", "1 io"},
+		"2JN": {"2 Ioannis", "2 io"},
+		"3JN": {"3 Ioannis", "3 io"},
+		"JUD": {"Iudae", "iud"},
+		"REV": {"Apocalypsis", "apoc"},
+
+		"TOB": {"Tobiae", "tobias", "tob"},
+		"JDT": {"Iudith", "idt"},
+		"ESG": {"Additamenta Esther", "esther graece"},
+		"WIS": {"Sapientia", "sap"},
+		"SIR": {"Ecclesiasticus", "siracides", "eccli"},
+		"BAR": {"Baruch", "bar"},
+		"S3Y": {"Canticum trium puerorum", "oratio azariae"},
+		"SUS": {"Susanna", "sus"},
+		"BEL": {"Bel et draco", "bel"},
+		"1MA": {"1 Machabaeorum", "1 mach"},
+		"2MA": {"2 Machabaeorum", "2 mach"},
+		"3MA": {"3 Machabaeorum", "3 mach"},
+		"4MA": {"4 Machabaeorum", "4 mach"},
+		"1ES": {"3 Esdrae", "3 esd"},
+		"2ES": {"4 Esdrae", "4 esd"},
+		"MAN": {"Oratio Manassae", "man"},
+	},
+}
+
+// foldAccents lets "Genese" match "Genèse" and "Matthaus" match "Matthäus"
+var foldAccents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// localeLookup maps the folded, lowercase names and abbreviations of each locale to a book code
+var localeLookup map[Locale]map[string]BookCode
+
+func init() {
+	localeLookup = make(map[Locale]map[string]BookCode)
+	for loc, names := range localeBooks {
+		lookup := make(map[string]BookCode)
+		for code, variants := range names {
+			for _, v := range variants {
+				lookup[foldAccents.Replace(strings.ToLower(v))] = code
+			}
+		}
+		localeLookup[loc] = lookup
+	}
+}
+
+// parseBook finds the book at the start of the chunks, trying the locale's names before the English ones and USFM codes
+func (l Locale) parseBook(chunks []string) (book string, prefix rune, rest []string, err error) {
+	lookup, ok := localeLookup[l]
+	if !ok || len(chunks) == 0 {
+		return parseBook(chunks)
+	}
+
+	lcChunks := make([]string, len(chunks))
+	for i, c := range chunks {
+		lcChunks[i] = foldAccents.Replace(strings.ToLower(strings.TrimSuffix(c, ".")))
+	}
+	// "1.", "I" and "first" are all 1
+	if p, ok := prefixLookup[lcChunks[0]]; ok {
+		lcChunks[0] = string(p)
+	}
+
+	for i := len(chunks); i > 0; i-- {
+		if code, ok := lookup[strings.Join(lcChunks[:i], " ")]; ok {
+			b, p, _ := code.Book()
+			return b, p, chunks[i:], nil
+		}
+	}
+
+	return parseBook(chunks)
+}
+
+// name returns the name of a book in the locale, falling back to English
+func (l Locale) name(r *Reference) string {
+	if code, ok := r.BookCode(); ok {
+		if names, ok := localeBooks[l][code]; ok {
+			return names[0]
+		}
+	}
+	return r.bookName()
+}
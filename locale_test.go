@@ -0,0 +1,133 @@
+package oremus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		loc  Locale
+		n    Notation
+		in   string
+		want string
+	}{
+		{Spanish, Anglo, "Génesis 1:1", "Genesis 1:1"},
+		{Spanish, Anglo, "genesis 1:1", "Genesis 1:1"},
+		{Spanish, Anglo, "Jn 3:16", "John 3:16"},
+		{Spanish, Anglo, "1 Juan 4:8", "1 John 4:8"},
+		{Spanish, Anglo, "Hechos de los Apóstoles 2:1-11", "Acts 2:1-11"},
+		{Spanish, Continental, "Mt 5,1-12", "Matthew 5:1-12"},
+		{German, Anglo, "1 Mose 1", "Genesis 1"},
+		{German, Continental, "1. Mose 1,1-5", "Genesis 1:1-5"},
+		{German, Continental, "5. Mose 6,4", "Deuteronomy 6:4"},
+		{German, Anglo, "Matthäus 5:1-12", "Matthew 5:1-12"},
+		{German, Anglo, "Matthaus 5", "Matthew 5"},
+		{German, Continental, "Mk 1,1.4.7", "Mark 1:1,4,7"},
+		{German, Continental, "Offb 21,1-6", "Revelation 21:1-6"},
+		{German, Continental, "Jud 3", "Jude 1:3"},
+		{German, Continental, "Jes 40,1-11", "Isaiah 40:1-11"},
+		{German, Continental, "Joh 3,16f.", "John 3:16ff"},
+		{German, Anglo, "Isaiah 40", "Isaiah 40"},
+		{German, Anglo, "1SA 3", "1 Samuel 3"},
+		{French, Anglo, "Jean 3:16", "John 3:16"},
+		{French, Anglo, "Genese 1", "Genesis 1"},
+		{French, Anglo, "1 Rois 19:9", "1 Kings 19:9"},
+		{French, Continental, "Ésaïe 40,1", "Isaiah 40:1"},
+		{Latin, Anglo, "Ioannes 3:16", "John 3:16"},
+		{Latin, Anglo, "I Samuelis 3", "1 Samuel 3"},
+		{Latin, Anglo, "1 Regum 19", "1 Kings 19"},
+		{Latin, Anglo, "Psalmi 22", "Psalms 22"},
+		{German, Continental, "1 Mose 50,22 - 2 Mose 2,10", "Genesis 50:22-Exodus 2:10"},
+	}
+	for _, tc := range tests {
+		r, err := Parser{Locale: tc.loc, Notation: tc.n}.ParseReference(tc.in)
+		if err != nil {
+			t.Errorf("%s %s: unexpected error: %v", tc.loc, tc.in, err)
+			continue
+		}
+		if got := r.String(); got != tc.want {
+			t.Errorf("%s %s: got %q, want %q", tc.loc, tc.in, got, tc.want)
+		}
+	}
+
+	if _, err := (Parser{Locale: German}).ParseReference("Mose 1"); err == nil {
+		t.Errorf("expected an error for an unnumbered Mose")
+	}
+}
+
+func TestFormatLocale(t *testing.T) {
+	tests := []struct {
+		in   string
+		loc  Locale
+		n    Notation
+		want string
+	}{
+		{"gen 1:1-5", German, Continental, "1 Mose 1,1-5"},
+		{"gen 1:1-5", German, Anglo, "1 Mose 1:1-5"},
+		{"john 3:16", Spanish, Anglo, "Juan 3:16"},
+		{"matt 5:1-12", German, Anglo, "Matthäus 5:1-12"},
+		{"john 3:16", French, Anglo, "Jean 3:16"},
+		{"john 3:16", Latin, Anglo, "Ioannes 3:16"},
+		{"ps 23", Latin, Anglo, "Psalmi 23"},
+		{"1 john 4:7-12,16", Spanish, Continental, "1 Juan 4,7-12.16"},
+		{"gen 50:22 - ex 2:10", German, Continental, "1 Mose 50,22-2 Mose 2,10"},
+		{"luke 24:13-35 (36-49)", French, Anglo, "Luc 24:13-35 (36-49)"},
+		{"john 3:16", English, Continental, "John 3,16"},
+	}
+	for _, tc := range tests {
+		r, err := ParseReference(tc.in)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.in, err)
+		}
+		p := Parser{Locale: tc.loc, Notation: tc.n}
+		got := p.Format(r)
+		if got != tc.want {
+			t.Errorf("%s in %s: got %q, want %q", tc.in, tc.loc, got, tc.want)
+		}
+		back, err := p.ParseReference(got)
+		if err != nil || back.String() != r.String() {
+			t.Errorf("%s in %s: did not round-trip, %v %v", tc.in, tc.loc, back, err)
+		}
+	}
+
+	got, err := Parser{Locale: German, Notation: Continental}.CleanReference("1. mose 1,1-5; 2,4; joh 1,1")
+	if err != nil || got != "1 Mose 1,1-5; 2,4; Johannes 1,1" {
+		t.Errorf("got %q %v", got, err)
+	}
+}
+
+func TestLocaleTables(t *testing.T) {
+	for loc, names := range localeBooks {
+		for code := range bookCodeLookup {
+			if _, ok := names[code]; !ok {
+				t.Errorf("%s has no name for %s", loc, code)
+			}
+		}
+		for code, variants := range names {
+			for _, v := range variants {
+				if got := localeLookup[loc][foldAccents.Replace(strings.ToLower(v))]; got != code {
+					t.Errorf("%s: %q is %s, want %s", loc, v, got, code)
+				}
+			}
+
+			b, p, _ := code.Book()
+			r := &Reference{Book: b, Prefix: p, ChapterVerseRange: []ChapterVerseRange{{StartChapter: 1, EndChapter: 1, StartVerse: 1, EndVerse: 1}}}
+			lp := Parser{Locale: loc}
+			back, err := lp.ParseReference(lp.Format(r))
+			if err != nil {
+				t.Errorf("%s: %s: %v", loc, lp.Format(r), err)
+				continue
+			}
+			if c, _ := back.BookCode(); c != code {
+				t.Errorf("%s: %s parsed as %s", loc, lp.Format(r), c)
+			}
+		}
+	}
+}
+
+func TestLocaleString(t *testing.T) {
+	if German.String() != "German" || Latin.String() != "Latin" || Locale(9).String() != "Locale(9)" {
+		t.Errorf("unexpected names %s %s %s", German, Latin, Locale(9))
+	}
+}
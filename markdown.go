@@ -0,0 +1,128 @@
+package oremus
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MarkdownRenderer renders a Passage as Markdown
+// paragraphs become blocks separated by blank lines, poetry lines end in hard line breaks and keep their indentation
+type MarkdownRenderer struct {
+	VerseNumbers bool                // show verse numbers as superscripts and chapter breaks in bold, the passage must be fetched WithVerseNumbers
+	DivineName   func(string) string // how to write the divine name, nil means SmallCaps
+	Indent       int                 // non-breaking spaces per level of poetry indentation, 0 means 4
+}
+
+// Render implements Renderer
+func (m MarkdownRenderer) Render(p *Passage) string {
+	indent := m.Indent
+	if indent <= 0 {
+		indent = 4
+	}
+
+	var buf strings.Builder
+	for i, para := range p.Paragraphs {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		for j, line := range para.Lines {
+			if j > 0 {
+				// two trailing spaces are a hard line break
+				buf.WriteString("  \n")
+			}
+			// Markdown strips ordinary leading spaces (or makes a code block of them), non-breaking spaces survive
+			buf.WriteString(strings.Repeat("\u00a0", line.Indent*indent))
+			buf.WriteString(m.line(line))
+		}
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+// line renders the runs of a line
+func (m MarkdownRenderer) line(line Line) string {
+	divine := m.DivineName
+	if divine == nil {
+		divine = SmallCaps
+	}
+
+	var buf strings.Builder
+	for _, r := range line.Runs {
+		switch r.Kind {
+		case VerseNumberRun:
+			if m.VerseNumbers {
+				buf.WriteString(superscript(r.Number))
+			}
+		case ChapterNumberRun:
+			if m.VerseNumbers {
+				buf.WriteString("**" + strconv.Itoa(r.Number) + "** ")
+			}
+		case DivineNameRun:
+			buf.WriteString(escapeMarkdown(divine(r.Text)))
+		case EmphasisRun:
+			txt := strings.TrimSpace(r.Text)
+			// keep the surrounding spaces outside the markers or they do not count as emphasis
+			if strings.HasPrefix(r.Text, " ") {
+				buf.WriteString(" ")
+			}
+			buf.WriteString("*" + escapeMarkdown(txt) + "*")
+			if strings.HasSuffix(r.Text, " ") {
+				buf.WriteString(" ")
+			}
+		default:
+			buf.WriteString(escapeMarkdown(r.Text))
+		}
+	}
+	return escapeLineStart(strings.TrimSpace(buf.String()))
+}
+
+// smallCaps maps lowercase letters to their Unicode small capital forms
+var smallCaps = map[rune]rune{
+	'a': 'ᴀ', 'b': 'ʙ', 'c': 'ᴄ', 'd': 'ᴅ', 'e': 'ᴇ', 'f': 'ꜰ', 'g': 'ɢ', 'h': 'ʜ', 'i': 'ɪ',
+	'j': 'ᴊ', 'k': 'ᴋ', 'l': 'ʟ', 'm': 'ᴍ', 'n': 'ɴ', 'o': 'ᴏ', 'p': 'ᴘ', 'r': 'ʀ', 's': 'ꜱ',
+	't': 'ᴛ', 'u': 'ᴜ', 'v': 'ᴠ', 'w': 'ᴡ', 'y': 'ʏ', 'z': 'ᴢ',
+}
+
+// SmallCaps writes the lowercase letters of s as Unicode small capitals, "Lord" becomes "Lᴏʀᴅ"
+func SmallCaps(s string) string {
+	return strings.Map(func(r rune) rune {
+		if sc, ok := smallCaps[r]; ok {
+			return sc
+		}
+		return r
+	}, s)
+}
+
+// superscripts are the Unicode superscript digits 0-9
+var superscripts = []rune("⁰¹²³⁴⁵⁶⁷⁸⁹")
+
+// superscript writes n in Unicode superscript digits, anything but digits is written as is
+func superscript(n int) string {
+	s := strconv.Itoa(n)
+	out := []rune(s)
+	for i, r := range out {
+		if r < '0' || r > '9' {
+			return s
+		}
+		out[i] = superscripts[r-'0']
+	}
+	return string(out)
+}
+
+// markdownEscaper backslash-escapes characters with inline meaning in Markdown
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`, `<`, `\<`, `>`, `\>`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// lineStart matches text that would start a heading, list or quote at the beginning of a line
+var lineStart = regexp.MustCompile(`^(\d+)([.)])|^([#+-])`)
+
+// escapeLineStart escapes block-level markers at the start of a line
+func escapeLineStart(s string) string {
+	return lineStart.ReplaceAllString(s, `$1\$2$3`)
+}
@@ -0,0 +1,62 @@
+package oremus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownPoetry(t *testing.T) {
+	p := mustParse(t, fixture(t, "psalm-23.html"))
+	out := MarkdownRenderer{}.Render(p)
+
+	want := "The Lᴏʀᴅ is my shepherd, I shall not want.  \n" +
+		"\u00a0\u00a0\u00a0\u00a0He makes me lie down in green pastures;  \n" +
+		"he leads me beside still waters;  \n"
+	if !strings.HasPrefix(out, want) {
+		t.Errorf("got\n%q\nwant prefix\n%q", out, want)
+	}
+	if strings.Count(out, "\n\n") != 2 {
+		t.Errorf("expected 3 blocks:\n%s", out)
+	}
+	if !strings.HasSuffix(out, "my whole life long.\n") {
+		t.Errorf("unexpected end: %q", out)
+	}
+}
+
+func TestMarkdownDivineName(t *testing.T) {
+	p := mustParse(t, fixture(t, "psalm-23.html"))
+	out := MarkdownRenderer{DivineName: strings.ToUpper}.Render(p)
+	if !strings.HasPrefix(out, "The LORD is my shepherd") {
+		t.Errorf("custom mapping not used: %q", out)
+	}
+}
+
+func TestMarkdownVerseNumbers(t *testing.T) {
+	in := `<div class="bibletext"><p><span class="cc">2</span>Thus the heavens were finished. <sup class="ii">12</sup>And on the <nn>seventh</nn> day</p></div>`
+	p := mustParse(t, in)
+
+	if got := (MarkdownRenderer{}).Render(p); got != "Thus the heavens were finished. And on the *seventh* day\n" {
+		t.Errorf("verse numbers should be off by default: %q", got)
+	}
+	if got := (MarkdownRenderer{VerseNumbers: true}).Render(p); got != "**2** Thus the heavens were finished. ¹²And on the *seventh* day\n" {
+		t.Errorf("unexpected verse numbers: %q", got)
+	}
+
+	if got := superscript(-3); got != "-3" {
+		t.Errorf("superscript(-3): got %q", got)
+	}
+	p = &Passage{Paragraphs: []Paragraph{{Lines: []Line{{Runs: []Run{{Kind: VerseNumberRun, Number: -3}, {Kind: TextRun, Text: "And"}}}}}}}
+	if got := (MarkdownRenderer{VerseNumbers: true}).Render(p); got != `\-3And`+"\n" {
+		t.Errorf("unexpected negative verse number: %q", got)
+	}
+}
+
+func TestMarkdownEscape(t *testing.T) {
+	in := `<div class="bibletext"><p>1. not a [list] *really*</p><p># nor a heading</p></div>`
+	p := mustParse(t, in)
+
+	want := "1\\. not a \\[list\\] \\*really\\*\n\n\\# nor a heading\n"
+	if got := (MarkdownRenderer{}).Render(p); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
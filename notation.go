@@ -0,0 +1,56 @@
+package oremus
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Notation is the punctuation used between chapters and verses
+type Notation int
+
+const (
+	// Anglo separates chapter and verse with a colon and lists verses with commas, "Mark 1:1,4,7"
+	Anglo Notation = iota
+	// Continental separates chapter and verse with a comma and lists verses with dots, "Mark 1,1.4.7"
+	Continental
+)
+
+// String returns the name of the notation
+func (n Notation) String() string {
+	switch n {
+	case Anglo:
+		return "Anglo"
+	case Continental:
+		return "Continental"
+	default:
+		return "Notation(" + strconv.Itoa(int(n)) + ")"
+	}
+}
+
+// continentalIn and continentalOut swap the punctuation between continental and anglo notation
+var (
+	continentalIn  = strings.NewReplacer(",", ":", ".", ",")
+	continentalOut = strings.NewReplacer(":", ",", ", ", ".", ",", ".")
+)
+
+// toAnglo rewrites the chapter and verse portion of a reference into anglo notation for the parser
+func (n Notation) toAnglo(in string) (string, error) {
+	if n != Continental {
+		return in, nil
+	}
+	if strings.ContainsRune(in, ':') {
+		return "", errors.New("continental notation separates chapter and verse with a comma")
+	}
+	// a dot at the end closes an abbreviation, "Joh 3,16f.", rather than listing another verse
+	in = strings.TrimSuffix(strings.TrimRight(in, " "), ".")
+	return continentalIn.Replace(in), nil
+}
+
+// format rewrites a reference written in anglo notation into n
+func (n Notation) format(in string) string {
+	if n == Continental {
+		return continentalOut.Replace(in)
+	}
+	return in
+}
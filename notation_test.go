@@ -0,0 +1,86 @@
+package oremus
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNotationRoundTrip(t *testing.T) {
+	tests := []struct {
+		anglo       string
+		continental string
+	}{
+		{"Genesis 1:1-5", "Genesis 1,1-5"},
+		{"Mark 1:1,4,7", "Mark 1,1.4.7"},
+		{"Genesis 1:1-2:4a", "Genesis 1,1-2,4a"},
+		{"John 3:16ff", "John 3,16ff"},
+		{"Genesis 1,7", "Genesis 1.7"},
+		{"Genesis 1-2", "Genesis 1-2"},
+		{"Psalms 23", "Psalms 23"},
+		{"Luke 24:13-35 (36-49)", "Luke 24,13-35 (36-49)"},
+		{"Genesis 50:22-Exodus 2:10", "Genesis 50,22-Exodus 2,10"},
+		{"1 Samuel 3:1-10,19", "1 Samuel 3,1-10.19"},
+	}
+	anglo, continental := Parser{Notation: Anglo}, Parser{Notation: Continental}
+	for _, tc := range tests {
+		a, err := anglo.ParseReference(tc.anglo)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.anglo, err)
+		}
+		c, err := continental.ParseReference(tc.continental)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.continental, err)
+		}
+		if a.bookName() != c.bookName() || a.EndBook != c.EndBook || !slices.Equal(a.ChapterVerseRange, c.ChapterVerseRange) {
+			t.Errorf("%s and %s parsed differently: %+v %+v", tc.anglo, tc.continental, a, c)
+		}
+		if got := anglo.Format(c); got != tc.anglo {
+			t.Errorf("%s: anglo got %q, want %q", tc.continental, got, tc.anglo)
+		}
+		if got := continental.Format(a); got != tc.continental {
+			t.Errorf("%s: continental got %q, want %q", tc.anglo, got, tc.continental)
+		}
+		if a.String() != anglo.Format(a) {
+			t.Errorf("%s: String and the anglo Format differ", tc.anglo)
+		}
+	}
+}
+
+func TestParseContinental(t *testing.T) {
+	tests := map[string]string{
+		"gen 1,1-5":             "Genesis 1,1-5",
+		"Gen. 1, 1 - 5":         "Genesis 1,1-5",
+		"1. sam 3,1-10":         "1 Samuel 3,1-10",
+		"mark 1,1.4.7":          "Mark 1,1.4.7",
+		"isa 40,1-11; 41,1-5":   "Isaiah 40,1-11; 41,1-5",
+		"ps 31,1-5.[6-8].15-16": "Psalms 31,1-5.[6-8].15-16",
+		"gen 1,3-end":           "Genesis 1,3-31",
+		"gen 50,22 - ex 2,10":   "Genesis 50,22-Exodus 2,10",
+		"john 3,16f.":           "John 3,16ff",
+		"mark 1,1.4.7.":         "Mark 1,1.4.7",
+	}
+	for in, want := range tests {
+		got, err := Parser{Notation: Continental}.CleanReference(in)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: got %q, want %q", in, got, want)
+		}
+	}
+
+	// a dot is not a separator in anglo notation
+	if _, err := ParseReference("gen 1.1"); err == nil {
+		t.Errorf("expected an error for gen 1.1 in anglo notation")
+	}
+	if _, err := (Parser{Notation: Continental}).ParseReference("gen 1:1"); err == nil {
+		t.Errorf("expected an error for gen 1:1 in continental notation")
+	}
+}
+
+func TestNotationString(t *testing.T) {
+	if Anglo.String() != "Anglo" || Continental.String() != "Continental" || Notation(7).String() != "Notation(7)" {
+		t.Errorf("unexpected names %s %s %s", Anglo, Continental, Notation(7))
+	}
+}
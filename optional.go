@@ -0,0 +1,46 @@
+package oremus
+
+// hasOptional reports whether any of the reference's verses are marked optional
+func (r *Reference) hasOptional() bool {
+	for _, cv := range r.ChapterVerseRange {
+		if cv.Optional {
+			return true
+		}
+	}
+	return false
+}
+
+// IncludeOptional returns a copy of the reference with the optional verses read as part of the passage, "Luke 24:13-49"
+// ranges are left as written, "Luke 24:13-35 (36-49)" becomes "Luke 24:13-35,36-49"
+func (r *Reference) IncludeOptional() *Reference {
+	out := *r
+	out.ChapterVerseRange = make([]ChapterVerseRange, len(r.ChapterVerseRange))
+	for i, cv := range r.ChapterVerseRange {
+		cv.Optional, cv.OptionalBracket, cv.OptionalComma = false, unset, false
+		out.ChapterVerseRange[i] = cv
+	}
+	return &out
+}
+
+// DropOptional returns a copy of the reference without the optional verses, "Luke 24:13-35"
+// it returns nil when every verse is optional
+func (r *Reference) DropOptional() *Reference {
+	out := *r
+	out.ChapterVerseRange = nil
+	for _, cv := range r.ChapterVerseRange {
+		if !cv.Optional {
+			out.ChapterVerseRange = append(out.ChapterVerseRange, cv)
+		}
+	}
+	if len(out.ChapterVerseRange) == 0 && len(r.ChapterVerseRange) > 0 {
+		return nil
+	}
+	return &out
+}
+
+// WithOptionalVerses chooses whether verses a lectionary marks as optional are fetched, by default they are
+func WithOptionalVerses(on bool) Option {
+	return func(c *Client) {
+		c.omitOptional = !on
+	}
+}
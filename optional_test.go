@@ -0,0 +1,158 @@
+package oremus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseOptional(t *testing.T) {
+	tests := map[string]string{
+		"Luke 24:13-35 (36-49)":      "Luke 24:13-35 (36-49)",
+		"Psalm 31:1-5, [6-8], 15-16": "Psalm 31:1-5, [6-8], 15-16",
+		"Isaiah 9:1-4 [5-7]":         "Isaiah 9:1-4 [5-7]",
+		"mark 1:1-8 (9-11, 14-15)":   "Mark 1:1-8 (9-11,14-15)",
+		"gen 1 (2)":                  "Genesis 1 (2)",
+		"gen (1:1-5)":                "Genesis (1:1-5)",
+		"john 20:19-23 (24:1-3)":     "John 20:19-23 (24:1-3)",
+		"ex 12:1-4 (5-10), 11-14":    "Exodus 12:1-4 (5-10), 11-14",
+		"luke 2:1-14 (15-20); 3:1":   "Luke 2:1-14 (15-20); 3:1",
+		"isa 9:1-4[5-7]":             "Isaiah 9:1-4 [5-7]",
+		"ps 31:1-5,[6-8],15-16":      "Psalms 31:1-5, [6-8], 15-16",
+		"mark 1:1-8, (9-11, 14-15)":  "Mark 1:1-8, (9-11,14-15)",
+		"isa 9:1-4 [5-7], 10":        "Isaiah 9:1-4 [5-7], 10",
+	}
+	for in, want := range tests {
+		got, err := CleanReference(in)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: got %q, want %q", in, got, want)
+		}
+		again, err := CleanReference(got)
+		if err != nil || again != got {
+			t.Errorf("%s: did not round-trip, %q %v", in, again, err)
+		}
+	}
+
+	r, err := ParseReference("Psalm 31:1-5, [6-8], 15-16")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []ChapterVerseRange{
+		{StartChapter: 31, EndChapter: 31, StartVerse: 1, EndVerse: 5},
+		{StartChapter: 31, EndChapter: 31, StartVerse: 6, EndVerse: 8, Optional: true, OptionalBracket: '[', OptionalComma: true},
+		{StartChapter: 31, EndChapter: 31, StartVerse: 15, EndVerse: 16},
+	}
+	if len(r.ChapterVerseRange) != len(want) {
+		t.Fatalf("got %+v, want %+v", r.ChapterVerseRange, want)
+	}
+	for i := range want {
+		if r.ChapterVerseRange[i] != want[i] {
+			t.Errorf("range %d: got %+v, want %+v", i, r.ChapterVerseRange[i], want[i])
+		}
+	}
+
+	bad := []string{
+		"luke 24:13-35 (36-49",
+		"luke 24:13-35 36-49)",
+		"luke 24:13-35 (36-49]",
+		"luke 24:13-35 ((36-49))",
+		"luke ()",
+		"acts 2:1-11 (12-13) (14-21)",
+		"acts 2:1-11 (12-13), (14-21)",
+		"acts 2:1-11 [12-13](14-21)",
+		"luke 24:13-35 (36-49) 50",
+		"luke 24:13-35 (36-49)-50",
+	}
+	for _, in := range bad {
+		if s, err := CleanReference(in); err == nil {
+			t.Errorf("%s: expected error, got %q", in, s)
+		}
+	}
+}
+
+func TestIncludeDropOptional(t *testing.T) {
+	tests := []struct {
+		in      string
+		include string
+		drop    string
+	}{
+		{"luke 24:13-35 (36-49)", "Luke 24:13-35,36-49", "Luke 24:13-35"},
+		{"ps 31:1-5, [6-8], 15-16", "Psalms 31:1-5,6-8,15-16", "Psalms 31:1-5,15-16"},
+		{"gen 1:1-5", "Genesis 1:1-5", "Genesis 1:1-5"},
+	}
+	for _, tc := range tests {
+		r, err := ParseReference(tc.in)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.in, err)
+		}
+		before := r.String()
+		if got := r.IncludeOptional().String(); got != tc.include {
+			t.Errorf("%s: include got %q, want %q", tc.in, got, tc.include)
+		}
+		if got := r.DropOptional().String(); got != tc.drop {
+			t.Errorf("%s: drop got %q, want %q", tc.in, got, tc.drop)
+		}
+		if r.String() != before {
+			t.Errorf("%s: reference was modified", tc.in)
+		}
+	}
+
+	r, _ := ParseReference("gen (1:1-5)")
+	if got := r.DropOptional(); got != nil {
+		t.Errorf("expected nil for an optional reference, got %q", got)
+	}
+}
+
+func TestWithOptionalVerses(t *testing.T) {
+	var got string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.FormValue("passage")
+		w.Write([]byte(minimalLection))
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		opts []Option
+		in   string
+		want string
+	}{
+		{nil, "luke 24:13-35 (36-49)", "Luke 24:13-35,36-49"},
+		{[]Option{WithOptionalVerses(true)}, "luke 24:13-35 (36-49)", "Luke 24:13-35,36-49"},
+		{[]Option{WithOptionalVerses(false)}, "luke 24:13-35 (36-49)", "Luke 24:13-35"},
+		{[]Option{WithOptionalVerses(false)}, "isa 9:1-4 [5-7]; ps 96", "Isaiah 9:1-4; Psalms 96"},
+		{[]Option{WithOptionalVerses(false)}, "luke 2:1-14; luke (2:15-20)", "Luke 2:1-14"},
+	}
+	for _, tc := range tests {
+		c := NewClient(append(tc.opts, WithBaseURL(ts.URL))...)
+		if _, err := c.GetPassage(context.Background(), tc.in); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.in, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: sent %q, want %q", tc.in, got, tc.want)
+		}
+	}
+
+	got = ""
+	c := NewClient(WithBaseURL(ts.URL), WithOptionalVerses(false))
+	if _, err := c.GetPassage(context.Background(), "gen (1:1-5)"); err == nil {
+		t.Errorf("expected an error when every verse is optional")
+	}
+	if got != "" {
+		t.Errorf("sent %q for a reference that is all optional", got)
+	}
+}
+
+func TestUSFMOptional(t *testing.T) {
+	r, err := ParseReference("gen 1:1-5 (6-8), 9")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s, err := r.USFM(); err != nil || s != "GEN 1:1-5,6-8,9" {
+		t.Errorf("USFM: got %q %v", s, err)
+	}
+}
@@ -4,129 +4,153 @@ import (
 	"bytes"
 	"context"
 	"golang.org/x/net/html"
-	"io"
-	"log"
-	"net/http"
-	"net/url"
+	"log/slog"
 	"strings"
 )
 
-// Get fetches a passage from bible.oremus.org, parses the result and returns the text formatted as simple HTML
-func Get(ctx context.Context, ref string) (string, error) {
-	c := http.Client{}
-	data := url.Values{}
-	cleanref := strings.Trim(ref, "	")
-	data.Set("passage", cleanref)
-	data.Set("vnum", "no")
-	data.Set("fnote", "no")
-	data.Set("heading", "no")
-	data.Set("show_ref", "no")
-	data.Set("show_adj", "no")
-	data.Set("omithidden", "yes")
+// discardLogger is used when no logger is configured
+var discardLogger = slog.New(slog.DiscardHandler)
 
-	resp, err := c.PostForm("https://bible.oremus.org/", data)
-	if err != nil {
-		log.Println(err.Error())
-		return "", err
-	}
+// defaultClient is used by the package-level functions
+var defaultClient = NewClient()
 
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Println(err.Error())
-		return "", err
-	}
+// Get fetches a passage from bible.oremus.org, parses the result and returns the text formatted as simple HTML
+// it uses the default Client settings, use NewClient for control over the form options
+func Get(ctx context.Context, ref string) (string, error) {
+	return defaultClient.Get(ctx, ref)
+}
 
-	parsed := parse(string(body[:]))
-	return parsed, nil
+// GetPassage fetches a passage from bible.oremus.org and returns the parsed structure
+func GetPassage(ctx context.Context, ref string) (*Passage, error) {
+	return defaultClient.GetPassage(ctx, ref)
 }
 
 // parse is a special purpose parser for bible.oremus.org's results
-func parse(in string) string {
+// tags it does not understand are logged at Debug
+func parse(in string, logger *slog.Logger) (*Passage, error) {
 	z := html.NewTokenizer(strings.NewReader(in))
-	var out = bytes.Buffer{}
+	var b passageBuilder
 	var inLection = false
+	var foundLection = false
+	var errorPage = false
 	var passageDepth = 0
-	var prevIsTextToken = false
+	// the kind of text each open tag inside the lection contributes
+	var kinds []RunKind
+
+	current := func() RunKind {
+		if len(kinds) == 0 {
+			return TextRun
+		}
+		return kinds[len(kinds)-1]
+	}
 
 	for {
 		tt := z.Next()
 		switch tt {
 		case html.ErrorToken:
 			// hit EOF, quit parsing
-			return out.String()
+			b.breakParagraph()
+			switch {
+			case len(b.p.Paragraphs) > 0:
+				return &b.p, nil
+			case errorPage:
+				return nil, ErrPassageNotFound
+			case !foundLection:
+				return nil, ErrNoLection
+			default:
+				// an empty lection means oremus had no text for the reference
+				return nil, ErrPassageNotFound
+			}
 		case html.TextToken:
 			if inLection {
-				if prevIsTextToken {
-					out.WriteRune(' ')
-				}
-				txt := z.Text()
-				out.WriteString(strings.TrimSpace(string(txt)))
-				prevIsTextToken = true
+				b.text(current(), string(z.Text()))
+			} else if isNotFound(z.Text()) {
+				errorPage = true
 			}
-		case html.StartTagToken:
+		case html.StartTagToken, html.SelfClosingTagToken:
 			tn, hasAttr := z.TagName()
-			if inLection {
-				prevIsTextToken = false
+			tag := string(tn)
+			var class string
+			for hasAttr {
+				ta, val, more := z.TagAttr()
+				hasAttr = more
+				if string(ta) == "class" {
+					class = string(val)
+				}
+			}
 
-				switch string(tn) {
-				case "p":
-					out.WriteString("<p>")
-				case "nn":
-					out.WriteString("\n<i>")
-				case "span":
-					out.WriteString("\n<span class='adonai'>")
-				default:
-					log.Printf("unprocessed open tag %+v\n", string(tn))
+			if !inLection {
+				// oremus tags the lection class="bibletext"
+				// we pay attention to only this block
+				if class == "bibletext" && tt == html.StartTagToken {
+					inLection = true
+					foundLection = true
+					passageDepth = 0
+					kinds = kinds[:0]
+					b.breakParagraph()
+				}
+				if class == "error" {
+					errorPage = true
 				}
-				passageDepth++
+				continue
+			}
+
+			b.prevIsText = false
+			if tag == "br" {
+				b.breakLine()
+				continue
+			}
+			if tt == html.SelfClosingTagToken {
+				logger.Debug("unprocessed tag", "tag", tag, "token", "self-close", "depth", passageDepth)
+				continue
 			}
-			// oremus tags the lection <p class="bibletext">
-			// we pay attention to only this block
-			if hasAttr {
-				for hasAttr {
-					ta, val, attr := z.TagAttr()
-					hasAttr = attr
-					if string(ta) == "class" && string(val) == "bibletext" {
-						inLection = true
-					}
+
+			kind := current()
+			switch tag {
+			case "p":
+				b.breakParagraph()
+			case "nn":
+				kind = EmphasisRun
+			case "sup":
+				kind = VerseNumberRun
+			case "span":
+				if class == "cc" {
+					kind = ChapterNumberRun
+				} else {
+					kind = DivineNameRun
 				}
+			default:
+				logger.Debug("unprocessed tag", "tag", tag, "token", "open", "depth", passageDepth)
 			}
+			kinds = append(kinds, kind)
+			passageDepth++
 		case html.EndTagToken:
 			if inLection {
-				prevIsTextToken = false
+				b.prevIsText = false
 
-				tn, _ := z.TagName()
-				switch string(tn) {
-				case "p":
-					out.WriteString("</p>\n")
-				case "nn":
-					out.WriteString("</i>\n")
-				case "span":
-					out.WriteString("</span>\n")
-				default:
-					log.Printf("unprocessed close tag %+v\n", string(tn))
-				}
-				if passageDepth == 0 { // found the </p> to close class="bibletext" -- quit processing
+				if passageDepth == 0 { // found the tag closing class="bibletext" -- quit processing
 					inLection = false
+					b.breakParagraph()
+					continue
 				}
-				passageDepth--
-			}
-		case html.SelfClosingTagToken:
-			if inLection {
-				prevIsTextToken = false
 
 				tn, _ := z.TagName()
 				switch string(tn) {
-				case "br":
-					out.WriteString("<br />")
+				case "p":
+					b.breakParagraph()
+				case "nn", "sup", "span":
 				default:
-					log.Printf("unprocessed self-close tag <%s />\n", tn)
+					logger.Debug("unprocessed tag", "tag", string(tn), "token", "close", "depth", passageDepth)
 				}
+				passageDepth--
+				kinds = kinds[:len(kinds)-1]
 			}
 		}
 	}
+}
 
-	// not reached
-	return out.String()
+// isNotFound recognizes the message on the oremus error page
+func isNotFound(txt []byte) bool {
+	lc := bytes.ToLower(txt)
+	return bytes.Contains(lc, []byte("passage not found")) || bytes.Contains(lc, []byte("is not a valid passage"))
 }
@@ -0,0 +1,325 @@
+package oremus
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// osisBooks maps the prefixed canonical name of each book ("1 John") to its OSIS abbreviation ("1John")
+var osisBooks = map[string]string{
+	"Genesis":         "Gen",
+	"Exodus":          "Exod",
+	"Leviticus":       "Lev",
+	"Numbers":         "Num",
+	"Deuteronomy":     "Deut",
+	"Joshua":          "Josh",
+	"Judges":          "Judg",
+	"Ruth":            "Ruth",
+	"1 Samuel":        "1Sam",
+	"2 Samuel":        "2Sam",
+	"1 Kings":         "1Kgs",
+	"2 Kings":         "2Kgs",
+	"1 Chronicles":    "1Chr",
+	"2 Chronicles":    "2Chr",
+	"Ezra":            "Ezra",
+	"Nehemiah":        "Neh",
+	"Esther":          "Esth",
+	"Job":             "Job",
+	"Psalms":          "Ps",
+	"Proverbs":        "Prov",
+	"Ecclesiastes":    "Eccl",
+	"Song of Songs":   "Song",
+	"Isaiah":          "Isa",
+	"Jeremiah":        "Jer",
+	"Lamentations":    "Lam",
+	"Ezekiel":         "Ezek",
+	"Daniel":          "Dan",
+	"Hosea":           "Hos",
+	"Joel":            "Joel",
+	"Amos":            "Amos",
+	"Obadiah":         "Obad",
+	"Jonah":           "Jonah",
+	"Micah":           "Mic",
+	"Nahum":           "Nah",
+	"Habakkuk":        "Hab",
+	"Zephaniah":       "Zeph",
+	"Haggai":          "Hag",
+	"Zechariah":       "Zech",
+	"Malachi":         "Mal",
+	"Matthew":         "Matt",
+	"Mark":            "Mark",
+	"Luke":            "Luke",
+	"John":            "John",
+	"Acts":            "Acts",
+	"Romans":          "Rom",
+	"1 Corinthians":   "1Cor",
+	"2 Corinthians":   "2Cor",
+	"Galatians":       "Gal",
+	"Ephesians":       "Eph",
+	"Philippians":     "Phil",
+	"Colossians":      "Col",
+	"1 Thessalonians": "1Thess",
+	"2 Thessalonians": "2Thess",
+	"1 Timothy":       "1Tim",
+	"2 Timothy":       "2Tim",
+	"Titus":           "Titus",
+	"Philemon":        "Phlm",
+	"Hebrews":         "Heb",
+	"James":           "Jas",
+	"1 Peter":         "1Pet",
+	"2 Peter":         "2Pet",
+	"1 John":          "1John",
+	"2 John":          "2John",
+	"3 John":          "3John",
+	"Jude":            "Jude",
+	"Revelation":      "Rev",
+
+	"Tobit":                  "Tob",
+	"Judith":                 "Jdt",
+	"Additions to Esther":    "AddEsth",
+	"Wisdom":                 "Wis",
+	"Sirach":                 "Sir",
+	"Baruch":                 "Bar",
+	"Song of the Three Jews": "PrAzar",
+	"Susanna":                "Sus",
+	"Bel and the Dragon":     "Bel",
+	"1 Maccabees":            "1Macc",
+	"2 Maccabees":            "2Macc",
+	"3 Maccabees":            "3Macc",
+	"4 Maccabees":            "4Macc",
+	"1 Esdras":               "1Esd",
+	"2 Esdras":               "2Esd",
+	"Prayer of Manasseh":     "PrMan",
+}
+
+// osisLookup maps lowercase OSIS abbreviations back to a book and prefix
+var osisLookup map[string]Reference
+
+func init() {
+	osisLookup = make(map[string]Reference)
+	for name, osis := range osisBooks {
+		r := Reference{Book: name}
+		if len(name) > 2 && name[1] == ' ' {
+			r.Prefix = rune(name[0])
+			r.Book = name[2:]
+		}
+		osisLookup[strings.ToLower(osis)] = r
+	}
+	// SWORD modules keep Psalm 151 in a book of its own
+	osisLookup["addps"] = Reference{Book: "Psalms"}
+}
+
+// osisBook returns the OSIS abbreviation of the reference's book
+func (r *Reference) osisBook() (string, bool) {
+	name := r.bookName()
+	if name == "Psalm" {
+		name = "Psalms"
+	}
+	osis, ok := osisBooks[name]
+	return osis, ok
+}
+
+// OSIS returns the reference as a space-separated list of osisRefs, one per ChapterVerseRange, e.g. "Gen.1.1-Gen.2.4 Gen.3"
+// partial verses use the OSIS grain ("Gen.1.1!a"); ff is expanded to the end of the chapter
+func (r *Reference) OSIS() (string, error) {
+	book, ok := r.osisBook()
+	if !ok {
+		return "", fmt.Errorf("no OSIS abbreviation for %s", r.bookName())
+	}
+	if len(r.ChapterVerseRange) == 0 {
+		return book, nil
+	}
+	if r.crossesBooks() {
+		start, end := r.endpoints()
+		endBook, ok := end.osisBook()
+		if !ok {
+			return "", fmt.Errorf("no OSIS abbreviation for %s", end.bookName())
+		}
+		s, e := start.ChapterVerseRange[0], end.ChapterVerseRange[0]
+		return osisID(book, s.StartChapter, s.StartVerse, s.StartVerseSuffix) + "-" + osisID(endBook, e.StartChapter, e.StartVerse, e.StartVerseSuffix), nil
+	}
+
+	chapters, _ := r.chapters()
+	first, _ := r.chapterRange()
+	out := make([]string, 0, len(r.ChapterVerseRange))
+	for _, cv := range r.ChapterVerseRange {
+		if cv.StartChapter == 0 {
+			out = append(out, book)
+			continue
+		}
+		if cv.EndVerseSuffix == 'f' || (cv.StartVerseSuffix == 'f' && cv.EndVerse == cv.StartVerse) {
+			if i := cv.EndChapter - first; i >= 0 && i < len(chapters) {
+				cv.EndVerse = chapters[i]
+			}
+			cv.EndVerseSuffix = unset
+			cv.StartVerseSuffix = unset
+		}
+
+		start := osisID(book, cv.StartChapter, cv.StartVerse, cv.StartVerseSuffix)
+		end := osisID(book, cv.EndChapter, cv.EndVerse, cv.EndVerseSuffix)
+		if cv.StartVerse != 0 && cv.EndVerse == cv.StartVerse && cv.EndChapter == cv.StartChapter {
+			// a single verse, the suffix on the start applies to the whole
+			out = append(out, start)
+			continue
+		}
+		if start == end {
+			out = append(out, start)
+			continue
+		}
+		out = append(out, start+"-"+end)
+	}
+	return strings.Join(out, " "), nil
+}
+
+// osisID formats a single osisID, leaving off a zero verse
+func osisID(book string, chapter, verse int, suffix rune) string {
+	var buf strings.Builder
+	buf.WriteString(book)
+	buf.WriteRune('.')
+	buf.WriteString(strconv.Itoa(chapter))
+	if verse != 0 {
+		buf.WriteRune('.')
+		buf.WriteString(strconv.Itoa(verse))
+		if suffix == 'a' || suffix == 'b' {
+			buf.WriteRune('!')
+			buf.WriteRune(suffix)
+		}
+	}
+	return buf.String()
+}
+
+// ParseOSIS parses a space-separated list of osisRefs such as "Gen.1.1-Gen.2.4 1John.4.8"
+// consecutive osisRefs in the same book are combined into a single Reference, an osisRef that ends in another book sets EndBook
+func ParseOSIS(in string) ([]*Reference, error) {
+	var out []*Reference
+	for _, osisRef := range strings.Fields(in) {
+		ref, cv, err := parseOSISRef(osisRef)
+		if err != nil {
+			return nil, err
+		}
+		if n := len(out); n > 0 && out[n-1].bookName() == ref.bookName() && cv.StartChapter != 0 && len(out[n-1].ChapterVerseRange) > 0 && !out[n-1].crossesBooks() && !ref.crossesBooks() {
+			out[n-1].ChapterVerseRange = append(out[n-1].ChapterVerseRange, cv)
+			continue
+		}
+		if cv.StartChapter != 0 {
+			ref.ChapterVerseRange = []ChapterVerseRange{cv}
+		}
+		out = append(out, ref)
+	}
+	if len(out) == 0 {
+		return nil, errors.New("empty reference")
+	}
+	return out, nil
+}
+
+// parseOSISRef parses a single osisID or range of osisIDs
+func parseOSISRef(in string) (*Reference, ChapterVerseRange, error) {
+	var cv ChapterVerseRange
+
+	// drop the work, e.g. "KJV:Gen.1.1"
+	if i := strings.LastIndexByte(in, ':'); i >= 0 {
+		in = in[i+1:]
+	}
+
+	startID, endID, isRange := strings.Cut(in, "-")
+	ref, startChapter, startVerse, startSuffix, err := parseOSISID(startID)
+	if err != nil {
+		return nil, cv, err
+	}
+	cv.StartChapter, cv.StartVerse, cv.StartVerseSuffix = startChapter, startVerse, startSuffix
+	cv.EndChapter, cv.EndVerse = startChapter, startVerse
+
+	if isRange {
+		endRef, endChapter, endVerse, endSuffix, err := parseOSISID(endID)
+		if err != nil {
+			return nil, cv, err
+		}
+		cv.EndChapter, cv.EndVerse, cv.EndVerseSuffix = endChapter, endVerse, endSuffix
+		if endRef.bookName() != ref.bookName() {
+			// Gen.50.22-Exod.2.10 runs across books, as in parseCrossBook
+			if cv.StartChapter == 0 || cv.EndChapter == 0 {
+				return nil, cv, fmt.Errorf("osisRef %q spans whole books", in)
+			}
+			if ref.Ordinal(Protestant) >= endRef.Ordinal(Protestant) {
+				return nil, cv, fmt.Errorf("%s comes after %s", ref.bookName(), endRef.bookName())
+			}
+			ref.EndBook, ref.EndPrefix = endRef.Book, endRef.Prefix
+		}
+
+		if cv.StartVerse != 0 && cv.EndVerse == 0 && cv.EndChapter != 0 {
+			// Gen.1.5-Gen.2 runs to the end of chapter 2
+			chapters, _ := endRef.chapters()
+			first, last := endRef.chapterRange()
+			if cv.EndChapter < first || cv.EndChapter > last {
+				return nil, cv, fmt.Errorf("osisRef %q ends in an unknown chapter", in)
+			}
+			cv.EndVerse = chapters[cv.EndChapter-first]
+		}
+	}
+
+	if (cv.StartChapter == 0) != (cv.EndChapter == 0) {
+		return nil, cv, fmt.Errorf("osisRef %q mixes a book with a chapter", in)
+	}
+	if cv.EndVerse == cv.StartVerse && cv.EndChapter == cv.StartChapter && cv.EndVerseSuffix != unset {
+		cv.StartVerseSuffix, cv.EndVerseSuffix = cv.EndVerseSuffix, unset
+	}
+	if ref.crossesBooks() {
+		return ref, cv, nil
+	}
+	if err := cv.Validate(); err != nil {
+		return nil, cv, err
+	}
+	return ref, cv, nil
+}
+
+// parseOSISID parses "Gen", "Gen.1" or "Gen.1.1!a"
+func parseOSISID(in string) (ref *Reference, chapter, verse int, suffix rune, err error) {
+	id, grain, hasGrain := strings.Cut(in, "!")
+	if hasGrain {
+		if grain != "a" && grain != "b" {
+			return nil, 0, 0, unset, fmt.Errorf("unsupported grain %q in osisID %q", grain, in)
+		}
+		suffix = rune(grain[0])
+	}
+
+	parts := strings.Split(id, ".")
+	if len(parts) > 3 {
+		return nil, 0, 0, unset, fmt.Errorf("invalid osisID %q", in)
+	}
+	book, ok := osisLookup[strings.ToLower(parts[0])]
+	if !ok {
+		return nil, 0, 0, unset, fmt.Errorf("unknown OSIS book %q", parts[0])
+	}
+	ref = &book
+
+	nums := make([]int, len(parts)-1)
+	for i, p := range parts[1:] {
+		n, err := strconv.Atoi(p)
+		if err != nil || n <= 0 {
+			return nil, 0, 0, unset, fmt.Errorf("invalid osisID %q", in)
+		}
+		nums[i] = n
+	}
+
+	if strings.EqualFold(parts[0], "AddPs") {
+		// AddPs.1.3 is Psalm 151:3
+		if len(nums) > 0 && nums[0] != 1 {
+			return nil, 0, 0, unset, fmt.Errorf("invalid osisID %q", in)
+		}
+		nums = append([]int{psalm151}, nums[min(len(nums), 1):]...)
+	}
+
+	switch len(nums) {
+	case 2:
+		verse = nums[1]
+		fallthrough
+	case 1:
+		chapter = nums[0]
+	}
+	if suffix != unset && verse == 0 {
+		return nil, 0, 0, unset, fmt.Errorf("grain without a verse in osisID %q", in)
+	}
+	return ref, chapter, verse, suffix, nil
+}
@@ -0,0 +1,152 @@
+package oremus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOSIS(t *testing.T) {
+	tests := map[string]string{
+		"gen 1:1":             "Gen.1.1",
+		"gen 1:1-2:4":         "Gen.1.1-Gen.2.4",
+		"gen 1":               "Gen.1",
+		"gen 1-3":             "Gen.1-Gen.3",
+		"gen 1-2:3":           "Gen.1-Gen.2.3",
+		"gen 1:1-5,7,9-10":    "Gen.1.1-Gen.1.5 Gen.1.7 Gen.1.9-Gen.1.10",
+		"gen 1,3":             "Gen.1 Gen.3",
+		"genesis":             "Gen",
+		"1 john 4:8":          "1John.4.8",
+		"iii john 15":         "3John.1.15",
+		"1 sam 3:1-10":        "1Sam.3.1-1Sam.3.10",
+		"2 kings 2:1-12":      "2Kgs.2.1-2Kgs.2.12",
+		"psalm 23":            "Ps.23",
+		"song of songs 2:8":   "Song.2.8",
+		"mark 1:1a":           "Mark.1.1!a",
+		"mark 1:1b-8":         "Mark.1.1!b-Mark.1.8",
+		"mark 1:1-8a":         "Mark.1.1-Mark.1.8!a",
+		"gen 1:26ff":          "Gen.1.26-Gen.1.31",
+		"sir 44:1-15":         "Sir.44.1-Sir.44.15",
+		"2 macc 7:1":          "2Macc.7.1",
+		"song of the three 1": "PrAzar.1",
+	}
+	for in, want := range tests {
+		r, err := ParseReference(in)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", in, err)
+		}
+		got, err := r.OSIS()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: got %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseOSIS(t *testing.T) {
+	tests := map[string]string{
+		"Gen.1.1":                         "Genesis 1:1",
+		"Gen.1.1-Gen.2.4":                 "Genesis 1:1-2:4",
+		"Gen.1":                           "Genesis 1",
+		"Gen.1-Gen.3":                     "Genesis 1-3",
+		"Gen":                             "Genesis",
+		"1John.4.8":                       "1 John 4:8",
+		"1Sam.3.1-1Sam.3.10":              "1 Samuel 3:1-10",
+		"Gen.1.1-Gen.1.5 Gen.1.7":         "Genesis 1:1-5,7",
+		"Gen.1.1 Exod.3.1-Exod.3.6":       "Genesis 1:1; Exodus 3:1-6",
+		"Mark.1.1!b-Mark.1.8":             "Mark 1:1b-8",
+		"Mark.1.8!a":                      "Mark 1:8a",
+		"KJV:John.3.16":                   "John 3:16",
+		"Gen.1.5-Gen.2":                   "Genesis 1:5-2:25",
+		"AddPs":                           "Psalms 151",
+		"AddPs.1.3":                       "Psalms 151:3",
+		"4Macc.17.11-4Macc.17.22":         "4 Maccabees 17:11-22",
+		"gen.1.1":                         "Genesis 1:1",
+		"Gen.1.1-Gen.1.5 Gen.1.7 Gen.1.9": "Genesis 1:1-5,7,9",
+		"Gen.1.1-Exod.1.1":                "Genesis 1:1-Exodus 1:1",
+		"Gen.50.22-Exod.2":                "Genesis 50:22-Exodus 2:25",
+		"1Sam.31-2Sam.1 2Sam.2":           "1 Samuel 31-2 Samuel 1; 2 Samuel 2",
+	}
+	for in, want := range tests {
+		refs, err := ParseOSIS(in)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", in, err)
+			continue
+		}
+		if got := joinReferences(refs); got != want {
+			t.Errorf("%s: got %q, want %q", in, got, want)
+		}
+	}
+
+	bad := []string{
+		"",
+		"Genesis.1.1",
+		"Exod.1.1-Gen.1.1",
+		"Gen-Exod.1.1",
+		"Gen.1-Exod",
+		"Gen.a",
+		"Gen.0",
+		"Gen.1.1.1",
+		"Gen.1!a",
+		"Gen.1.1!c",
+		"Gen.2-Gen.1",
+		"Gen-Gen.1",
+	}
+	for _, in := range bad {
+		if refs, err := ParseOSIS(in); err == nil {
+			t.Errorf("%q: expected error, got %v", in, refs)
+		}
+	}
+}
+
+func TestOSISRoundTrip(t *testing.T) {
+	tests := []string{
+		"1 John 4:8",
+		"2 Corinthians 5:17-6:2",
+		"Genesis 1",
+		"Genesis 1-2",
+		"Genesis 1:1-2:4",
+		"Genesis 1:1-5,7,9-10",
+		"Genesis 1:1,3:1",
+		"Mark 1:1b-8",
+		"Isaiah 40:1-11; Mark 1:1-8",
+		"1 Maccabees 2:1-28",
+		"Jude 1:25",
+		"Genesis 50:22-Exodus 2:10",
+		"1 Samuel 31:8b-2 Samuel 1:10a",
+		"Malachi 4-Matthew 1",
+	}
+	for _, in := range tests {
+		refs, err := ParseReferences(in)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", in, err)
+		}
+		var osis []string
+		for _, r := range refs {
+			s, err := r.OSIS()
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", in, err)
+			}
+			osis = append(osis, s)
+		}
+
+		back, err := ParseOSIS(strings.Join(osis, " "))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", in, err)
+		}
+		if got := joinReferences(back); got != in {
+			t.Errorf("%s: round-tripped to %q via %q", in, got, osis)
+		}
+	}
+}
+
+// joinReferences formats references the way CleanReference does
+func joinReferences(refs []*Reference) string {
+	out := make([]string, len(refs))
+	for i, r := range refs {
+		out[i] = r.String()
+	}
+	return strings.Join(out, "; ")
+}
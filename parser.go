@@ -0,0 +1,17 @@
+package oremus
+
+// Parser reads and writes references with the book names of a Locale in a Notation
+// the zero Parser uses English names in anglo notation, as ParseReference and String do
+type Parser struct {
+	Locale   Locale
+	Notation Notation
+}
+
+// Format returns the normalized reference with the parser's book names and notation
+func (p Parser) Format(r *Reference) string {
+	if p.Locale == English {
+		return p.Notation.format(r.String())
+	}
+	end := &Reference{Book: r.EndBook, Prefix: r.EndPrefix}
+	return p.Notation.format(r.format(p.Locale.name(r), p.Locale.name(end)))
+}
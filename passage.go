@@ -0,0 +1,222 @@
+package oremus
+
+import (
+	"html"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Passage is the structured form of a lection returned by bible.oremus.org
+type Passage struct {
+	Reference  string
+	Version    Version
+	Paragraphs []Paragraph
+}
+
+// Paragraph is a block of text; prose has a single Line, poetry has one Line per line of verse
+type Paragraph struct {
+	Lines []Line
+}
+
+// Line is a run of text ending in a paragraph break or a <br />
+type Line struct {
+	Indent int // poetry indentation level, 0 for the first line of a stanza and for prose
+	Runs   []Run
+}
+
+// RunKind says how the text of a Run should be presented
+type RunKind int
+
+const (
+	TextRun          RunKind = iota // plain text
+	VerseNumberRun                  // a verse number, Number is set
+	ChapterNumberRun                // a chapter break, Number is set
+	DivineNameRun                   // the small-caps LORD
+	EmphasisRun                     // text oremus sets off in <nn>
+)
+
+// Run is a span of text of a single kind
+type Run struct {
+	Kind   RunKind
+	Text   string // whitespace is collapsed but not trimmed, so runs can be concatenated
+	Number int    // chapter or verse number for ChapterNumberRun and VerseNumberRun
+}
+
+// String returns the name of the kind
+func (k RunKind) String() string {
+	switch k {
+	case TextRun:
+		return "text"
+	case VerseNumberRun:
+		return "verse"
+	case ChapterNumberRun:
+		return "chapter"
+	case DivineNameRun:
+		return "divine-name"
+	case EmphasisRun:
+		return "emphasis"
+	default:
+		return "RunKind(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// HTML renders the passage as simple HTML, the format historically returned by Get
+func (p *Passage) HTML() string {
+	var buf strings.Builder
+	for _, para := range p.Paragraphs {
+		buf.WriteString("<p>")
+		for i, line := range para.Lines {
+			if i > 0 {
+				buf.WriteString("<br />")
+			}
+			for j, r := range line.Runs {
+				txt := html.EscapeString(strings.TrimSpace(r.Text))
+				switch r.Kind {
+				case VerseNumberRun:
+					buf.WriteString("<sup>" + txt + "</sup>")
+				case ChapterNumberRun:
+					buf.WriteString("\n<span class='chapter'>" + txt + "</span>\n")
+				case DivineNameRun:
+					buf.WriteString("\n<span class='adonai'>" + txt + "</span>\n")
+				case EmphasisRun:
+					buf.WriteString("\n<i>" + txt + "</i>\n")
+				default:
+					// keep the spaces between runs, except at the ends of the line and next to the runs set on lines of their own
+					txt = r.Text
+					if j == 0 || ownLine(line.Runs[j-1].Kind) {
+						txt = strings.TrimLeft(txt, " ")
+					}
+					if j == len(line.Runs)-1 || ownLine(line.Runs[j+1].Kind) {
+						txt = strings.TrimRight(txt, " ")
+					}
+					buf.WriteString(html.EscapeString(txt))
+				}
+			}
+		}
+		buf.WriteString("</p>\n")
+	}
+	return buf.String()
+}
+
+// ownLine reports whether HTML writes the kind of run between newlines
+func ownLine(k RunKind) bool {
+	return k == ChapterNumberRun || k == DivineNameRun || k == EmphasisRun
+}
+
+// passageBuilder accumulates runs into lines and paragraphs while parsing
+type passageBuilder struct {
+	p    Passage
+	para Paragraph
+	line Line
+	// the previous token was text, so new text of the same kind continues the last run
+	prevIsText bool
+	// whitespace was seen between runs that could not be attached to the previous run
+	pendingSpace bool
+}
+
+// text adds a text token of the given kind to the current line
+func (b *passageBuilder) text(kind RunKind, raw string) {
+	if len(b.line.Runs) == 0 {
+		// oremus indents poetry with leading &nbsp;s
+		raw = strings.TrimLeft(raw, " \t\r\n")
+		trimmed := strings.TrimLeft(raw, "\u00a0")
+		if n := utf8.RuneCountInString(raw) - utf8.RuneCountInString(trimmed); n > 0 {
+			b.line.Indent = (n + 3) / 4
+		}
+		raw = strings.TrimLeft(trimmed, " \t\r\n")
+		if raw == "" {
+			return
+		}
+	}
+
+	txt := collapseSpace(raw)
+	n := len(b.line.Runs)
+	if txt == " " {
+		// whitespace between tags
+		if n > 0 && isTextKind(b.line.Runs[n-1].Kind) {
+			if !strings.HasSuffix(b.line.Runs[n-1].Text, " ") {
+				b.line.Runs[n-1].Text += " "
+			}
+		} else {
+			b.pendingSpace = true
+		}
+		return
+	}
+
+	if kind == VerseNumberRun || kind == ChapterNumberRun {
+		num := strings.TrimSpace(txt)
+		// anything but a positive number is kept as text, renderers index digits
+		if i, err := strconv.Atoi(num); err == nil && i > 0 {
+			b.line.Runs = append(b.line.Runs, Run{Kind: kind, Text: num, Number: i})
+			b.prevIsText = false
+			b.pendingSpace = false
+			return
+		}
+		kind = TextRun
+	}
+
+	if b.pendingSpace && !strings.HasPrefix(txt, " ") {
+		txt = " " + txt
+	}
+	b.pendingSpace = false
+
+	if n > 0 && b.prevIsText && b.line.Runs[n-1].Kind == kind {
+		last := &b.line.Runs[n-1]
+		if !strings.HasSuffix(last.Text, " ") && !strings.HasPrefix(txt, " ") {
+			last.Text += " "
+		}
+		last.Text += txt
+	} else {
+		b.line.Runs = append(b.line.Runs, Run{Kind: kind, Text: txt})
+	}
+	b.prevIsText = true
+}
+
+// isTextKind reports whether runs of this kind carry passage text rather than a number
+func isTextKind(k RunKind) bool {
+	return k != VerseNumberRun && k != ChapterNumberRun
+}
+
+// breakLine ends the current line
+func (b *passageBuilder) breakLine() {
+	b.prevIsText = false
+	b.pendingSpace = false
+	if len(b.line.Runs) > 0 {
+		last := &b.line.Runs[len(b.line.Runs)-1]
+		last.Text = strings.TrimRight(last.Text, " ")
+		b.para.Lines = append(b.para.Lines, b.line)
+	}
+	b.line = Line{}
+}
+
+// breakParagraph ends the current line and paragraph
+func (b *passageBuilder) breakParagraph() {
+	b.breakLine()
+	if len(b.para.Lines) > 0 {
+		b.p.Paragraphs = append(b.p.Paragraphs, b.para)
+	}
+	b.para = Paragraph{}
+}
+
+// collapseSpace replaces every run of whitespace with a single space
+func collapseSpace(in string) string {
+	var buf strings.Builder
+	space := false
+	for _, r := range in {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if space {
+			buf.WriteRune(' ')
+			space = false
+		}
+		buf.WriteRune(r)
+	}
+	if space {
+		buf.WriteRune(' ')
+	}
+	return buf.String()
+}
@@ -0,0 +1,180 @@
+package oremus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fixture loads a recorded bible.oremus.org response from testdata
+func fixture(t *testing.T, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("unable to read fixture: %v", err)
+	}
+	return string(b)
+}
+
+// mustParse parses a response that is expected to contain a lection
+func mustParse(t *testing.T, in string) *Passage {
+	t.Helper()
+	p, err := parse(in, discardLogger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return p
+}
+
+// fixtureServer serves a recorded response for every request
+func fixtureServer(t *testing.T, name string) *httptest.Server {
+	t.Helper()
+	body := fixture(t, name)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestParseProse(t *testing.T) {
+	p := mustParse(t, fixture(t, "genesis-1-1-5.html"))
+
+	if len(p.Paragraphs) != 1 {
+		t.Fatalf("expected 1 paragraph, got %d", len(p.Paragraphs))
+	}
+	lines := p.Paragraphs[0].Lines
+	if len(lines) != 1 || len(lines[0].Runs) != 1 {
+		t.Fatalf("expected a single line with a single run, got %+v", lines)
+	}
+	r := lines[0].Runs[0]
+	if r.Kind != TextRun {
+		t.Errorf("expected text, got %s", r.Kind)
+	}
+	if !strings.HasPrefix(r.Text, "In the beginning") || !strings.HasSuffix(r.Text, "the first day.") {
+		t.Errorf("unexpected text %q", r.Text)
+	}
+}
+
+func TestParsePoetry(t *testing.T) {
+	p := mustParse(t, fixture(t, "psalm-23.html"))
+
+	if len(p.Paragraphs) != 3 {
+		t.Fatalf("expected 3 paragraphs, got %d", len(p.Paragraphs))
+	}
+
+	first := p.Paragraphs[0].Lines
+	if len(first) != 6 {
+		t.Fatalf("expected 6 lines, got %d", len(first))
+	}
+	if first[0].Indent != 0 || first[1].Indent != 1 {
+		t.Errorf("wrong indentation: %d %d", first[0].Indent, first[1].Indent)
+	}
+
+	want := []Run{
+		{Kind: TextRun, Text: "The "},
+		{Kind: DivineNameRun, Text: "Lord"},
+		{Kind: TextRun, Text: " is my shepherd, I shall not want."},
+	}
+	if len(first[0].Runs) != len(want) {
+		t.Fatalf("expected %d runs, got %+v", len(want), first[0].Runs)
+	}
+	for i, r := range first[0].Runs {
+		if r != want[i] {
+			t.Errorf("run %d: got %+v, want %+v", i, r, want[i])
+		}
+	}
+
+	if got := first[5].Runs[0].Text; got != "for his name’s sake." {
+		t.Errorf("entities not decoded: %q", got)
+	}
+}
+
+func TestParseNumbers(t *testing.T) {
+	in := `<div class="bibletext"><p><span class="cc">2</span>Thus the heavens <sup class="ii">2</sup>And on the seventh day</p></div>`
+	p := mustParse(t, in)
+
+	runs := p.Paragraphs[0].Lines[0].Runs
+	if len(runs) != 4 {
+		t.Fatalf("expected 4 runs, got %+v", runs)
+	}
+	if runs[0].Kind != ChapterNumberRun || runs[0].Number != 2 {
+		t.Errorf("expected chapter 2, got %+v", runs[0])
+	}
+	if runs[2].Kind != VerseNumberRun || runs[2].Number != 2 {
+		t.Errorf("expected verse 2, got %+v", runs[2])
+	}
+
+	p = mustParse(t, `<div class="bibletext"><p>Thus <sup class="ii">-3</sup>And <sup class="ii">0</sup>on</p></div>`)
+	for _, r := range p.Paragraphs[0].Lines[0].Runs {
+		if r.Kind != TextRun {
+			t.Errorf("expected non-positive verse numbers to be text, got %+v", r)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]error{
+		`<html><body><p>nothing to see</p></body></html>`:         ErrNoLection,
+		`<html><body><div class="bibletext"></div></body></html>`: ErrPassageNotFound,
+		fixture(t, "not-found.html"):                              ErrPassageNotFound,
+	}
+	for in, want := range tests {
+		p, err := parse(in, discardLogger)
+		if !errors.Is(err, want) {
+			t.Errorf("expected %v, got %v", want, err)
+		}
+		if p != nil {
+			t.Errorf("expected no passage, got %+v", p)
+		}
+	}
+}
+
+func TestHTML(t *testing.T) {
+	p := mustParse(t, fixture(t, "psalm-23.html"))
+	out := p.HTML()
+
+	if !strings.HasPrefix(out, "<p>The\n<span class='adonai'>Lord</span>\nis my shepherd") {
+		t.Errorf("unexpected start: %q", out)
+	}
+	if strings.Count(out, "<p>") != 3 || strings.Count(out, "</p>\n") != 3 {
+		t.Errorf("expected 3 paragraphs: %q", out)
+	}
+	if strings.Count(out, "<br />") != 16 {
+		t.Errorf("expected 16 line breaks: %q", out)
+	}
+
+	out = mustParse(t, fixture(t, "genesis-1-29-2-3-vnum.html")).HTML()
+	for _, want := range []string{
+		"<p><sup>29</sup>God said",
+		"for food. <sup>30</sup>And to every beast",
+		"And it was so. <sup>31</sup>God saw",
+		"<span class='chapter'>2</span>\nThus the heavens",
+		"creation.</p>\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+}
+
+func TestGetPassage(t *testing.T) {
+	ts := fixtureServer(t, "psalm-23.html")
+	c := NewClient(WithBaseURL(ts.URL))
+
+	p, err := c.GetPassage(context.Background(), "Psalm 23")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Reference != "Psalm 23" {
+		t.Errorf("wrong reference %q", p.Reference)
+	}
+	if len(p.Paragraphs) != 3 {
+		t.Errorf("expected 3 paragraphs, got %d", len(p.Paragraphs))
+	}
+}
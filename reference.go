@@ -3,6 +3,8 @@ package oremus
 import (
 	"errors"
 	"fmt"
+	"math"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -15,6 +17,8 @@ type Reference struct {
 	Book              string
 	ChapterVerseRange []ChapterVerseRange
 	Prefix            rune
+	EndBook           string // set when the reference ends in another book, "Genesis 50:22-Exodus 2:10"
+	EndPrefix         rune
 }
 
 // ChapterVerseRange is the chapter/verse portion of a reference "1:14-2:7a"
@@ -25,6 +29,9 @@ type ChapterVerseRange struct {
 	StartVerse       int
 	EndChapter       int
 	EndVerse         int
+	Optional         bool // verses a lectionary marks as optional, "Luke 24:13-35 (36-49)"
+	OptionalBracket  rune // the ( or [ the optional verses were written in, ( if unset
+	OptionalComma    bool // the optional verses were set off with a comma, "Psalm 31:1-5, [6-8], 15-16"
 }
 
 // some books have a prefix, this is used to normalize those to "1, 2, 3"
@@ -32,6 +39,7 @@ var prefixes = map[rune][]string{
 	'1': {"1", "1st", "i", "l", "first"},
 	'2': {"2", "2nd", "ii", "ll", "second"},
 	'3': {"3", "3rd", "iii", "lll", "third"},
+	'4': {"4", "4th", "iv", "fourth"},
 }
 
 // a list of known variations of book names (lowercase for ease of matching)
@@ -93,12 +101,26 @@ var books = map[string][]string{
 	"Peter":         {"peter"},
 	"Jude":          {"jude"},
 	"Revelation":    {"revelation", "rev"},
-	"Wisdom":        {"wisdom", "wis"},
+
+	// deuterocanonical books and the Apocrypha
+	"Tobit":                  {"tobit", "tob"},
+	"Judith":                 {"judith", "jdt"},
+	"Additions to Esther":    {"additions to esther", "add esth", "rest of esther", "greek esther"},
+	"Wisdom":                 {"wisdom", "wis", "wisdom of solomon"},
+	"Sirach":                 {"sirach", "sir", "ecclesiasticus", "ecclus"},
+	"Baruch":                 {"baruch", "bar"},
+	"Song of the Three Jews": {"song of the three jews", "song of the three young men", "song of the three", "song of three", "prayer of azariah", "azariah"},
+	"Susanna":                {"susanna", "sus"},
+	"Bel and the Dragon":     {"bel and the dragon", "bel"},
+	"Maccabees":              {"maccabees", "macc"},
+	"Esdras":                 {"esdras", "esd"},
+	"Prayer of Manasseh":     {"prayer of manasseh", "pr man", "manasseh"},
 }
 
 // books which have prefixes (John makes this complicated)
 var booksWithPrefix = map[string][]rune{
 	"samuel": {'1', '2'}, "kings": {'1', '2'}, "chronicles": {'1', '2'}, "corinthians": {'1', '2'}, "thessalonians": {'1', '2'}, "timothy": {'1', '2'}, "peter": {'1', '2'}, "john": {'1', '2', '3'},
+	"maccabees": {'1', '2', '3', '4'}, "esdras": {'1', '2'},
 }
 
 func allowedPrefix(book string, prefix rune) bool {
@@ -128,31 +150,61 @@ func init() {
 	}
 }
 
+// bookName returns the book with its prefix, e.g. "1 John"
+func (r *Reference) bookName() string {
+	if r.Prefix != unset {
+		return string(r.Prefix) + " " + r.Book
+	}
+	return r.Book
+}
+
 // String returns a normalized reference to a scripture passage
 func (r *Reference) String() string {
-	first := true
-	var prevChap int
+	return r.format(r.bookName(), r.endBookName())
+}
 
-	var buf strings.Builder
-	if r.Prefix != unset {
-		buf.WriteRune(r.Prefix)
-		buf.WriteRune(' ')
+// format writes the reference with the given names for the book and the book it ends in
+func (r *Reference) format(book, endBook string) string {
+	if r.crossesBooks() {
+		return r.crossBookString(book, endBook)
 	}
 
-	if r.Book != "" {
-		buf.WriteString(r.Book)
-	}
+	var buf strings.Builder
+	buf.WriteString(book)
+	r.writeChapterVerse(&buf)
+	return buf.String()
+}
+
+// writeChapterVerse writes the chapter and verse portion of the reference, with a leading space, e.g. " 1:1-5,7"
+func (r *Reference) writeChapterVerse(buf *strings.Builder) {
+	first := true
+	var prevChap int
 
 	if len(r.ChapterVerseRange) > 0 && r.ChapterVerseRange[0].StartChapter != 0 {
 		buf.WriteRune(' ')
 	}
 
-	for _, v := range r.ChapterVerseRange {
+	for i, v := range r.ChapterVerseRange {
+		// optional verses are grouped in the brackets they were written in, Luke 24:13-35 (36-49)
+		opens := v.Optional && (i == 0 || !r.ChapterVerseRange[i-1].Optional)
+		closed := i > 0 && r.ChapterVerseRange[i-1].Optional && !v.Optional
 		if !first {
-			buf.WriteString(",")
+			switch {
+			case opens && v.OptionalComma:
+				buf.WriteString(", ")
+			case opens:
+				buf.WriteRune(' ')
+			case closed:
+				buf.WriteString(", ")
+			default:
+				buf.WriteRune(',')
+			}
 		} else {
 			first = false
 		}
+		if opens {
+			buf.WriteRune(v.openBracket())
+		}
 
 		if v.StartChapter != prevChap {
 			buf.WriteString(strconv.Itoa(v.StartChapter))
@@ -195,10 +247,23 @@ func (r *Reference) String() string {
 				}
 			}
 		}
+		if v.Optional && (i == len(r.ChapterVerseRange)-1 || !r.ChapterVerseRange[i+1].Optional) {
+			if v.openBracket() == '[' {
+				buf.WriteRune(']')
+			} else {
+				buf.WriteRune(')')
+			}
+		}
 		prevChap = v.EndChapter
 	}
+}
 
-	return buf.String()
+// openBracket returns the bracket the optional verses are written in
+func (cv ChapterVerseRange) openBracket() rune {
+	if cv.OptionalBracket == '[' {
+		return '['
+	}
+	return '('
 }
 
 func parseBook(chunks []string) (book string, prefix rune, rest []string, err error) {
@@ -230,25 +295,42 @@ func parseBook(chunks []string) (book string, prefix rune, rest []string, err er
 		}
 	}
 
+	// USFM codes in any case, "1SA"; names come first, so "JUD" is Judges as it always was
+	if b, p, ok := BookCode(chunks[0]).Book(); ok {
+		return b, p, chunks[1:], nil
+	}
+
 	return "", 0, nil, errors.New("invalid book")
 }
 
 // CleanReference takes a string, returns a normalized string for a semi-colon separated list of scripture references
+// a reference in the same book as the one before it is written without the book, "Isaiah 40:1-11; 41:1-5"
 func CleanReference(in string) (string, error) {
-	r, err := ParseReferences(in)
+	return Parser{}.CleanReference(in)
+}
+
+// CleanReference normalizes references read and written with the parser's book names and notation
+func (p Parser) CleanReference(in string) (string, error) {
+	r, err := p.ParseReferences(in)
 	if err != nil {
 		return "", err
 	}
 
 	var buf strings.Builder
-	first := true
-	for _, v := range r {
-		if !first {
-			buf.WriteString("; ")
-		} else {
-			first = false
+	for i, v := range r {
+		if i == 0 {
+			buf.WriteString(p.Format(v))
+			continue
 		}
-		buf.WriteString(v.String())
+		buf.WriteString("; ")
+		// the book carries over, Isaiah 40:1-11; 41:1-5
+		if v.bookName() == r[i-1].endBookName() && !v.crossesBooks() && len(v.ChapterVerseRange) > 0 && v.ChapterVerseRange[0].StartChapter != 0 {
+			var cv strings.Builder
+			v.writeChapterVerse(&cv)
+			buf.WriteString(p.Notation.format(strings.TrimPrefix(cv.String(), " ")))
+			continue
+		}
+		buf.WriteString(p.Format(v))
 	}
 	return buf.String(), nil
 }
@@ -256,18 +338,29 @@ func CleanReference(in string) (string, error) {
 // ParseReference parses a free-form reference to a scripture passage and returns a []*Reference
 // use the stringer method to get a normalized string format back
 func ParseReferences(in string) ([]*Reference, error) {
+	return Parser{}.ParseReferences(in)
+}
+
+// ParseReferences parses a list of references written with the parser's book names and notation
+// English book names and USFM codes are accepted as well
+func (p Parser) ParseReferences(in string) ([]*Reference, error) {
 	var out []*Reference
+	var prev *Reference
 
 	refs := strings.Split(in, ";")
 	for _, r := range refs {
 		if r == "" {
 			continue
 		}
-		parsed, err := ParseReference(r)
+		parsed, err := parseReference(r, prev, p.Notation, p.Locale)
 		if err != nil {
 			return nil, err
 		}
 		out = append(out, parsed)
+		prev = parsed
+	}
+	if len(out) == 0 {
+		return nil, errors.New("empty reference")
 	}
 	return out, nil
 }
@@ -275,25 +368,102 @@ func ParseReferences(in string) ([]*Reference, error) {
 // ParseReference parses a single free-form reference to a scripture passage and returns a *Reference
 // use the stringer method to get a normalized string format back
 func ParseReference(in string) (*Reference, error) {
+	return Parser{}.ParseReference(in)
+}
+
+// ParseReference parses a single reference written with the parser's book names and notation
+func (p Parser) ParseReference(in string) (*Reference, error) {
+	return parseReference(in, nil, p.Notation, p.Locale)
+}
+
+// parseReference parses a reference, one that starts with a chapter ("41:1-5") takes its book from prev
+func parseReference(in string, prev *Reference, n Notation, loc Locale) (*Reference, error) {
 	newRef := Reference{}
 	var rest []string
 	var err error
 
-	newRef.Book, newRef.Prefix, rest, err = parseBook(strings.Fields(in))
+	if start, end, ok := splitCrossBook(in, loc); ok {
+		return parseCrossBook(start, end, prev, n, loc)
+	}
+
+	chunks := strings.Fields(in)
+	newRef.Book, newRef.Prefix, rest, err = loc.parseBook(chunks)
 	if err != nil {
-		return nil, err
+		if prev == nil || len(chunks) == 0 || !startsWithDigit(chunks[0]) {
+			return nil, err
+		}
+		// Isaiah 40:1-11; 41:1-5
+		newRef.Book, newRef.Prefix, rest = prev.Book, prev.Prefix, chunks
+		if prev.crossesBooks() {
+			newRef.Book, newRef.Prefix = prev.EndBook, prev.EndPrefix
+		}
 	}
 	if newRef.Book == "" {
 		return nil, errors.New("invalid book")
 	}
-	newRef.ChapterVerseRange, err = parseChapterVerse(strings.Join(rest, " "))
+	cv, err := n.toAnglo(strings.Join(rest, " "))
+	if err != nil {
+		return nil, err
+	}
+	newRef.ChapterVerseRange, err = parseChapterVerse(cv)
 	if err != nil {
 		return nil, err
 	}
+	newRef.singleChapter()
+	if err := newRef.resolveEnd(); err != nil {
+		return nil, err
+	}
 
 	return &newRef, nil
 }
 
+// startsWithDigit reports whether s begins with 0-9
+func startsWithDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+// singleChapter reads "Jude 25" as Jude 1:25, the usual way of citing books with one chapter
+// a bare 1 is left alone, "Jude 1" is the whole book
+func (r *Reference) singleChapter() {
+	chapters, ok := r.chapters()
+	if !ok || len(chapters) != 1 {
+		return
+	}
+	for i, cv := range r.ChapterVerseRange {
+		if cv.StartVerse != 0 || cv.EndChapter <= 1 {
+			continue
+		}
+		r.ChapterVerseRange[i] = ChapterVerseRange{
+			StartChapter: 1,
+			EndChapter:   1,
+			StartVerse:   cv.StartChapter,
+			EndVerse:     cv.EndChapter,
+		}
+	}
+}
+
+// endOfChapter marks an EndVerse written as "end" until ParseReference looks up the real verse
+const endOfChapter = math.MaxInt32
+
+// endWord matches "end" or "end of chapter" at the start of the input, Gen 1:3-end, Gen 1:26-2:end
+var endWord = regexp.MustCompile(`^(?i)end(\s+of(\s+the)?\s+chapter)?\b`)
+
+// resolveEnd replaces "end" with the number of the last verse of the chapter
+func (r *Reference) resolveEnd() error {
+	for i, cv := range r.ChapterVerseRange {
+		if cv.EndVerse != endOfChapter {
+			continue
+		}
+		chapters, ok := r.chapters()
+		first, last := r.chapterRange()
+		if !ok || cv.EndChapter < first || cv.EndChapter > last {
+			return fmt.Errorf("%s %d does not exist", r.bookName(), cv.EndChapter)
+		}
+		r.ChapterVerseRange[i].EndVerse = chapters[cv.EndChapter-first]
+	}
+	return nil
+}
+
 type parseState int
 
 const (
@@ -302,6 +472,7 @@ const (
 	stateAmbiguous                      // working on something after a -, could be endchapter or endverse
 	stateEndVerse                       // working on the end verse
 	stateAfterSuffix                    // after suffix, before -,;
+	stateListItem                       // after a , following a verse: a verse in the same chapter unless a : makes it a chapter
 )
 
 func parseChapterVerse(in string) ([]ChapterVerseRange, error) {
@@ -309,6 +480,10 @@ func parseChapterVerse(in string) ([]ChapterVerseRange, error) {
 	current := ChapterVerseRange{}      // the reference we are working on
 	var workbuf strings.Builder
 	var state parseState
+	var next int    // where to carry on after a word such as "end"
+	var closer rune // the ) or ] that ends the optional verses being read
+	var comma bool  // the optional verses being read follow a comma, Psalm 31:1-5, [6-8]
+	var closed bool // a bracket has just closed, only a comma or the end may follow
 
 	// a helper to save some labor below
 	var flushBuffer = func() (int, error) {
@@ -324,7 +499,88 @@ func parseChapterVerse(in string) ([]ChapterVerseRange, error) {
 		return asInt, nil
 	}
 
-	for _, r := range in {
+	// finishRange finishes the reference being worked on at a , or a bracket and starts a new one
+	finishRange := func() error {
+		i, err := flushBuffer()
+		if err != nil {
+			return err
+		}
+		switch state {
+		case stateStartChapter:
+			// whole chapter reference (7 in Gen 1,7)
+			current.StartChapter = i
+			current.EndChapter = i
+		case stateStartVerse:
+			// must be a single-verse reference (Gen 1:1)
+			if i == 0 {
+				return errors.New("missing verse")
+			}
+			current.StartVerse = i
+			current.EndVerse = i
+		case stateAmbiguous:
+			// if we have a verse, assume verse, otherwise assume chapter
+			if current.StartVerse != 0 {
+				// Gen 1:1-5
+				current.EndVerse = i
+			} else {
+				// Gen 1-2
+				current.EndChapter = i
+			}
+		case stateEndVerse:
+			// Gen 1:2-3:4
+			current.EndVerse = i
+		case stateListItem:
+			// catch double ,, after a verse
+			if i == 0 {
+				return nil
+			}
+			// Gen 7:1,4
+			current.StartVerse = i
+			current.EndVerse = i
+		case stateAfterSuffix:
+			// nothing
+		default:
+			return errors.New("comma in invalid state")
+		}
+
+		// catch double ,,
+		if current.StartChapter == 0 {
+			return nil
+		}
+
+		if err := current.Validate(); err != nil {
+			return err
+		}
+		current.Optional = closer != unset
+		if current.Optional {
+			current.OptionalBracket, current.OptionalComma = '(', comma
+			if closer == ']' {
+				current.OptionalBracket = '['
+			}
+		}
+		out = append(out, current)
+		prev := current
+		current = ChapterVerseRange{}
+		state = stateStartChapter
+		// prime the new reference in case of the following
+		if prev.StartVerse != 0 { // Gen 7:1,4,9 -- chapter 7 verses 1, 4 and 9; that is 3 ChapterVerseRanges
+			current.StartChapter = prev.EndChapter
+			current.EndChapter = prev.EndChapter
+			state = stateListItem
+		}
+		return nil
+	}
+
+	for i, r := range in {
+		if i < next {
+			continue
+		}
+		if closed && r != ' ' {
+			if r != ',' {
+				return nil, errors.New("optional verses must be followed by a comma")
+			}
+			closed = false
+		}
 		switch r {
 		case '1', '2', '3', '4', '5', '6', '7', '8', '9', '0':
 			workbuf.WriteRune(r)
@@ -336,6 +592,9 @@ func parseChapterVerse(in string) ([]ChapterVerseRange, error) {
 
 			switch state {
 			case stateStartVerse:
+				if i == 0 {
+					return nil, errors.New("missing verse")
+				}
 				current.StartVerse = i
 				current.EndVerse = i
 				current.StartVerseSuffix = r
@@ -344,59 +603,64 @@ func parseChapterVerse(in string) ([]ChapterVerseRange, error) {
 				current.EndVerse = i
 				current.EndVerseSuffix = r
 				state = stateAfterSuffix
+			case stateListItem:
+				// Gen 1:1,4b
+				if i == 0 {
+					return nil, errors.New("missing verse")
+				}
+				current.StartVerse = i
+				current.EndVerse = i
+				current.StartVerseSuffix = r
+				state = stateAfterSuffix
 			case stateAfterSuffix:
 				// ignore (ff)
 			default:
 				return nil, errors.New("suffix but not on verse")
 			}
+		case 'e', 'E': // "end" or "end of chapter"
+			word := endWord.FindString(in[i:])
+			if word == "" {
+				return nil, fmt.Errorf("invalid character in reference: %d", r)
+			}
+			if (state != stateAmbiguous && state != stateEndVerse) || workbuf.Len() > 0 || current.StartVerse == 0 {
+				return nil, errors.New("end must follow a verse and a dash")
+			}
+			current.EndVerse = endOfChapter
+			state = stateAfterSuffix
+			next = i + len(word)
 		case ',', unset:
 			// , ends the current reference and starts a new one
-			i, err := flushBuffer()
-			if err != nil {
+			if err := finishRange(); err != nil {
 				return nil, err
 			}
-			switch state {
-			case stateStartChapter:
-				// whole chapter reference (7 in Gen 1,7)
-				current.StartChapter = i
-				current.EndChapter = i
-			case stateStartVerse:
-				// must be a single-verse reference (Gen 1:1)
-				current.StartVerse = i
-				current.EndVerse = i
-			case stateAmbiguous:
-				// if we have a verse, assume verse, otherwise assume chapter
-				if current.StartVerse != 0 {
-					// Gen 1:1-5
-					current.EndVerse = i
-				} else {
-					// Gen 1-2
-					current.EndChapter = i
+		case '(', '[':
+			// optional verses, Luke 24:13-35 (36-49); the bracket ends the reference before it like a ,
+			if closer != unset {
+				return nil, errors.New("nested optional verses")
+			}
+			if n := len(out); n > 0 && out[n-1].Optional && workbuf.Len() == 0 && (state == stateStartChapter || state == stateListItem) {
+				// Acts 2:1-11 (12-13), (14-21) would be written back as one group
+				return nil, errors.New("optional verses must be separated by verses that are not")
+			}
+			if workbuf.Len() > 0 || (state != stateStartChapter && state != stateListItem) {
+				if err := finishRange(); err != nil {
+					return nil, err
 				}
-			case stateEndVerse:
-				// Gen 1:2-3:4
-				current.EndVerse = i
-			default:
-				return nil, errors.New("comma in invalid state")
 			}
-
-			// catch double ,,
-			if current.StartChapter == 0 {
-				continue
+			closer = ')'
+			if r == '[' {
+				closer = ']'
 			}
-
-			if err := current.Validate(); err != nil {
-				return nil, err
+			comma = strings.HasSuffix(strings.TrimRight(in[:i], " "), ",")
+		case ')', ']':
+			if r != closer {
+				return nil, fmt.Errorf("unmatched %q", r)
 			}
-			out = append(out, current)
-			cchap := current.StartChapter
-			current = ChapterVerseRange{}
-			// prime the new reference in case of the following
-			if cchap != 0 { // Gen 7:1,4,9 -- chapter 7 verses 1, 4 and 9; that is 3 ChapterVerseRanges
-				current.StartChapter = cchap
-				current.EndChapter = cchap
+			if err := finishRange(); err != nil {
+				return nil, err
 			}
-			state = stateStartChapter
+			closer = unset
+			closed = true
 		case '-', '—', '–': // hyphen, en dash, and em dashes all found in the wild
 			// - moves from the first part of a reference to the end of one (either chapter or verse)
 			i, err := flushBuffer()
@@ -408,7 +672,10 @@ func parseChapterVerse(in string) ([]ChapterVerseRange, error) {
 			case stateStartChapter:
 				current.StartChapter = i
 				current.EndChapter = i
-			case stateStartVerse:
+			case stateStartVerse, stateListItem:
+				if i == 0 {
+					return nil, errors.New("missing verse")
+				}
 				current.StartVerse = i
 				current.EndVerse = i
 			case stateAfterSuffix:
@@ -423,7 +690,7 @@ func parseChapterVerse(in string) ([]ChapterVerseRange, error) {
 				return nil, err
 			}
 			switch state {
-			case stateStartChapter:
+			case stateStartChapter, stateListItem:
 				// the "w" of a w:x-y:z
 				current.StartChapter = i
 				current.EndChapter = i
@@ -441,17 +708,35 @@ func parseChapterVerse(in string) ([]ChapterVerseRange, error) {
 		}
 	}
 
+	if closer != unset {
+		return nil, fmt.Errorf("missing %q", closer)
+	}
+	if end := strings.TrimRight(in, " "); strings.HasSuffix(end, ")") || strings.HasSuffix(end, "]") {
+		// the bracket ended the last reference, Luke 24:13-35 (36-49)
+		if len(out) == 0 {
+			return nil, errors.New("empty optional verses")
+		}
+		return out, nil
+	}
+
 	i, err := flushBuffer()
 	if err != nil {
 		return nil, err
 	}
 	switch state {
 	case stateStartChapter:
+		// nothing after the last comma (Gen 1:1,)
+		if i == 0 && len(out) > 0 {
+			return nil, errors.New("trailing comma")
+		}
 		// whole chapter reference (7 in Gen 1,7)
 		current.StartChapter = i
 		current.EndChapter = i
 	case stateStartVerse:
 		// must be a single-verse reference (Gen 1:1)
+		if i == 0 {
+			return nil, errors.New("missing verse")
+		}
 		current.StartVerse = i
 		current.EndVerse = i
 	case stateAmbiguous:
@@ -466,6 +751,14 @@ func parseChapterVerse(in string) ([]ChapterVerseRange, error) {
 	case stateEndVerse:
 		// Gen 1:2-3:4
 		current.EndVerse = i
+	case stateListItem:
+		// nothing after the last comma (Gen 1:1,)
+		if i == 0 {
+			return nil, errors.New("trailing comma")
+		}
+		// Gen 7:1,4
+		current.StartVerse = i
+		current.EndVerse = i
 	case stateAfterSuffix:
 		// nothing
 	default:
@@ -1,6 +1,7 @@
 package oremus
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -71,6 +72,63 @@ var refs = map[string]string{
 
 	// dashes
 	"gen 1:1—3": "Genesis 1:1-3",
+
+	// single-chapter books
+	"jude 25":      "Jude 1:25",
+	"jude 3-4":     "Jude 1:3-4",
+	"jude 1":       "Jude 1",
+	"obad 15,21":   "Obadiah 1:15,21",
+	"philemon 4-7": "Philemon 1:4-7",
+	"philemon 1":   "Philemon 1",
+	"2 john 12":    "2 John 1:12",
+	"3 john 1-4":   "3 John 1:1-4",
+	"3 john 14":    "3 John 1:14",
+	"jude 1:25":    "Jude 1:25",
+	"jude 3,5":     "Jude 1:3,5",
+
+	// verse lists stay in the chapter of the verse before them
+	"gen 1:4,6-10":       "Genesis 1:4,6-10",
+	"gen 10:1-7,12:9-11": "Genesis 10:1-7,12:9-11",
+
+	// the Apocrypha
+	"wis 7:26":                          "Wisdom 7:26",
+	"Wisdom of Solomon 3:1-9":           "Wisdom 3:1-9",
+	"ecclus 44:1-15":                    "Sirach 44:1-15",
+	"sir 38:1-4":                        "Sirach 38:1-4",
+	"bar 3:9-15,32-4:4":                 "Baruch 3:9-15,32-4:4",
+	"tob 8:4b-8":                        "Tobit 8:4b-8",
+	"jdt 9:1,11-14":                     "Judith 9:1,11-14",
+	"1 macc 2:1-28":                     "1 Maccabees 2:1-28",
+	"ii macc 7:1-2,9-14":                "2 Maccabees 7:1-2,9-14",
+	"3 maccabees 6":                     "3 Maccabees 6",
+	"iv macc 17:11-22":                  "4 Maccabees 17:11-22",
+	"4th maccabees 1":                   "4 Maccabees 1",
+	"1 esd 4:34-40":                     "1 Esdras 4:34-40",
+	"2 esdras 2:42-48":                  "2 Esdras 2:42-48",
+	"pr man 1-15":                       "Prayer of Manasseh 1:1-15",
+	"prayer of manasseh":                "Prayer of Manasseh",
+	"add esth 14:1-19":                  "Additions to Esther 14:1-19",
+	"song of the three young men 29-34": "Song of the Three Jews 1:29-34",
+	"prayer of azariah 35-65":           "Song of the Three Jews 1:35-65",
+	"sus 1-62":                          "Susanna 1:1-62",
+	"bel 23-42":                         "Bel and the Dragon 1:23-42",
+	"ps 151":                            "Psalms 151",
+
+	// end of chapter
+	"gen 1:3-end":                  "Genesis 1:3-31",
+	"Gen 1:3–end of chapter":       "Genesis 1:3-31",
+	"gen 1:3 - End of the Chapter": "Genesis 1:3-31",
+	"gen 1:26-2:end":               "Genesis 1:26-2:25",
+	"gen 1:26-end,2:1":             "Genesis 1:26-31,2:1",
+	"gen 1:3-END":                  "Genesis 1:3-31",
+
+	// book carry-over
+	"Isaiah 40:1-11; 41:1-5":       "Isaiah 40:1-11; 41:1-5",
+	"ps 23; 27:1-6":                "Psalms 23; 27:1-6",
+	"1 john 4:7-12; 5:1; 2 john 1": "1 John 4:7-12; 5:1; 2 John 1",
+	"gen 1:1; ex 3:1; 4:1":         "Genesis 1:1; Exodus 3:1; 4:1",
+	"jude 3; 20":                   "Jude 1:3; 1:20",
+	"gen 1; gen 2":                 "Genesis 1; 2",
 }
 
 // these are things that should not work, just checking the error messages (use `go test -v`)
@@ -101,9 +159,27 @@ var badrefs = []string{
 	"gen 2-1",
 	"gen 1:3-1",
 	"4 john 1",
+	"2 john 12-4",
+	"philemon 7-4",
+	"obad 21,",
+	"3 john 1:",
 	"2 genesis 1",
+	"3 esdras 1",
+	"5 macc 1",
 	"gen 1:1c",
 	"gen 1:1$",
+	"gen 1-end",
+	"gen 1:end",
+	"gen 1:1-5end",
+	"gen 1:1-ending",
+	"gen 1:3-e",
+	"gen 1:3-2:e",
+	"gen 1:1-\ue001",
+	"gen 1:3-end of",
+	"gen 1:3-endof chapter",
+	"41:1-5",
+	"gen 1; 2 genesis 1",
+	"gen 1; :1",
 }
 
 func TestParseReference(t *testing.T) {
@@ -201,3 +277,37 @@ func FuzzParseReference(f *testing.F) {
 		}
 	})
 }
+
+func TestVerseLists(t *testing.T) {
+	tests := map[string][]ChapterVerseRange{
+		"gen 1:4,6-10": {
+			{StartChapter: 1, EndChapter: 1, StartVerse: 4, EndVerse: 4},
+			{StartChapter: 1, EndChapter: 1, StartVerse: 6, EndVerse: 10},
+		},
+		"gen 10:1-7,12:9-11": {
+			{StartChapter: 10, EndChapter: 10, StartVerse: 1, EndVerse: 7},
+			{StartChapter: 12, EndChapter: 12, StartVerse: 9, EndVerse: 11},
+		},
+		"gen 1:2-3:4,6": {
+			{StartChapter: 1, EndChapter: 3, StartVerse: 2, EndVerse: 4},
+			{StartChapter: 3, EndChapter: 3, StartVerse: 6, EndVerse: 6},
+		},
+		"gen 1,3": {
+			{StartChapter: 1, EndChapter: 1},
+			{StartChapter: 3, EndChapter: 3},
+		},
+		"gen 1:1a,4b": {
+			{StartChapter: 1, EndChapter: 1, StartVerse: 1, EndVerse: 1, StartVerseSuffix: 'a'},
+			{StartChapter: 1, EndChapter: 1, StartVerse: 4, EndVerse: 4, StartVerseSuffix: 'b'},
+		},
+	}
+	for in, want := range tests {
+		r, err := ParseReference(in)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", in, err)
+		}
+		if !slices.Equal(r.ChapterVerseRange, want) {
+			t.Errorf("%s: got %+v, want %+v", in, r.ChapterVerseRange, want)
+		}
+	}
+}
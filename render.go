@@ -0,0 +1,23 @@
+package oremus
+
+// Renderer turns a Passage into text in some output format
+type Renderer interface {
+	Render(p *Passage) string
+}
+
+// HTMLRenderer renders simple HTML, the format Get has always returned
+type HTMLRenderer struct{}
+
+// Render implements Renderer
+func (HTMLRenderer) Render(p *Passage) string {
+	return p.HTML()
+}
+
+// WithRenderer sets the format of the string returned by Get, the default is HTMLRenderer
+func WithRenderer(r Renderer) Option {
+	return func(c *Client) {
+		if r != nil {
+			c.renderer = r
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package oremus
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// FFResolver decides where a passage cited with ff ("Mark 1:1ff") ends
+type FFResolver interface {
+	// ResolveFF returns the last verse of the passage starting at chapter:verse of the book
+	ResolveFF(book BookCode, chapter, verse int) (endChapter, endVerse int, err error)
+}
+
+// EndOfChapter resolves ff to the last verse of the chapter
+type EndOfChapter struct{}
+
+// ResolveFF implements FFResolver
+func (EndOfChapter) ResolveFF(book BookCode, chapter, verse int) (int, int, error) {
+	r, ok := bookCodeLookup[book]
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown book %q", book)
+	}
+	chapters, _ := r.chapters()
+	first, last := r.chapterRange()
+	if chapter < first || chapter > last {
+		return 0, 0, fmt.Errorf("%s %d does not exist", r.bookName(), chapter)
+	}
+	return chapter, chapters[chapter-first], nil
+}
+
+// SectionStart is the first verse of a section
+type SectionStart struct {
+	Chapter int
+	Verse   int
+}
+
+// Pericopes resolves ff to the end of the section, the verse before the next section starts
+// the table lists the first verse of each section, in order, keyed by book; books without an entry and the last section of a book end with the chapter
+type Pericopes map[BookCode][]SectionStart
+
+// ResolveFF implements FFResolver
+func (p Pericopes) ResolveFF(book BookCode, chapter, verse int) (int, int, error) {
+	i, found := slices.BinarySearchFunc(p[book], SectionStart{chapter, verse}, func(s, target SectionStart) int {
+		if s.Chapter != target.Chapter {
+			return s.Chapter - target.Chapter
+		}
+		return s.Verse - target.Verse
+	})
+	if found {
+		i++
+	}
+	if i >= len(p[book]) {
+		return EndOfChapter{}.ResolveFF(book, chapter, verse)
+	}
+
+	next := p[book][i]
+	if next.Verse > 1 {
+		return next.Chapter, next.Verse - 1, nil
+	}
+	// the next section starts a chapter, so this one ends with the previous chapter
+	return EndOfChapter{}.ResolveFF(book, next.Chapter-1, 1)
+}
+
+// ResolveFF returns a copy of the reference with every ff replaced by the end of the passage the resolver chooses
+func (r *Reference) ResolveFF(res FFResolver) (*Reference, error) {
+	out := *r
+	out.ChapterVerseRange = slices.Clone(r.ChapterVerseRange)
+	for i, cv := range out.ChapterVerseRange {
+		var chapter, verse int
+		switch {
+		case cv.EndVerseSuffix == 'f':
+			chapter, verse = cv.EndChapter, cv.EndVerse
+		case cv.StartVerseSuffix == 'f' && cv.EndChapter == cv.StartChapter && cv.EndVerse == cv.StartVerse:
+			chapter, verse = cv.StartChapter, cv.StartVerse
+		case cv.StartVerseSuffix == 'f':
+			// Gen 1:1ff-5 has an end already
+			out.ChapterVerseRange[i].StartVerseSuffix = unset
+			continue
+		default:
+			continue
+		}
+
+		code, ok := r.BookCode()
+		if !ok {
+			return nil, fmt.Errorf("unknown book %s", r.bookName())
+		}
+		endChapter, endVerse, err := res.ResolveFF(code, chapter, verse)
+		if err != nil {
+			return nil, err
+		}
+		cv.EndChapter, cv.EndVerse = endChapter, endVerse
+		if cv.StartVerseSuffix == 'f' {
+			cv.StartVerseSuffix = unset
+		}
+		cv.EndVerseSuffix = unset
+		if err := cv.Validate(); err != nil {
+			return nil, err
+		}
+		out.ChapterVerseRange[i] = cv
+	}
+	return &out, nil
+}
+
+// WithFFResolver resolves ff in references before they are sent to oremus, by default they are sent as written
+func WithFFResolver(res FFResolver) Option {
+	return func(c *Client) {
+		c.ffResolver = res
+	}
+}
+
+// endRange spots a range that runs to the "end" of a chapter, Gen 1:3-end, oremus does not understand it
+var endRange = regexp.MustCompile(`(?i)[-–—:]\s*end\b`)
+
+// resolve turns "end", and ff when the Client has an FFResolver, into verse numbers oremus understands
+// optional verses are kept or dropped as the Client is configured, oremus does not accept brackets
+// references we cannot parse or resolve are passed to oremus as-is, it is an error for every verse to be dropped
+func (c *Client) resolve(ref string) (string, error) {
+	changed := endRange.MatchString(ref) || strings.ContainsAny(ref, "([")
+	if c.ffResolver == nil && !changed {
+		return ref, nil
+	}
+	refs, err := ParseReferences(ref)
+	if err != nil {
+		return ref, nil
+	}
+
+	out := make([]string, 0, len(refs))
+	for _, r := range refs {
+		if c.ffResolver != nil {
+			resolved, err := r.ResolveFF(c.ffResolver)
+			if err != nil {
+				return ref, nil
+			}
+			if !slices.Equal(resolved.ChapterVerseRange, r.ChapterVerseRange) {
+				changed = true
+			}
+			r = resolved
+		}
+		if r.hasOptional() {
+			if c.omitOptional {
+				r = r.DropOptional()
+			} else {
+				r = r.IncludeOptional()
+			}
+			if r == nil {
+				// the whole reference was optional
+				continue
+			}
+		}
+		out = append(out, r.String())
+	}
+	if len(out) == 0 {
+		return "", errors.New("every verse is optional")
+	}
+	if !changed {
+		return ref, nil
+	}
+	return strings.Join(out, "; "), nil
+}
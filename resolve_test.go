@@ -0,0 +1,89 @@
+package oremus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveFF(t *testing.T) {
+	pericopes := Pericopes{
+		"MRK": {{1, 1}, {1, 9}, {1, 14}, {1, 21}, {2, 1}, {2, 13}},
+	}
+	tests := []struct {
+		in       string
+		resolver FFResolver
+		want     string
+	}{
+		{"mark 1:1ff", EndOfChapter{}, "Mark 1:1-45"},
+		{"mark 1:14ff", EndOfChapter{}, "Mark 1:14-45"},
+		{"mark 1:1ff", pericopes, "Mark 1:1-8"},
+		{"mark 1:10ff", pericopes, "Mark 1:10-13"},
+		{"mark 1:21ff", pericopes, "Mark 1:21-45"},
+		{"mark 1:40ff", pericopes, "Mark 1:40-45"},
+		{"mark 2:13ff", pericopes, "Mark 2:13-28"},
+		{"luke 15:1ff", pericopes, "Luke 15:1-32"},
+		{"mark 1:1-3,9ff", pericopes, "Mark 1:1-3,9-13"},
+		{"mark 1:1-4", pericopes, "Mark 1:1-4"},
+		{"mark 1:1-4ff", EndOfChapter{}, "Mark 1:1-45"},
+	}
+	for _, tc := range tests {
+		r, err := ParseReference(tc.in)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.in, err)
+		}
+		before := r.String()
+		resolved, err := r.ResolveFF(tc.resolver)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.in, err)
+			continue
+		}
+		if got := resolved.String(); got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.in, got, tc.want)
+		}
+		if r.String() != before {
+			t.Errorf("%s: the original reference was modified", tc.in)
+		}
+	}
+
+	// chapter 99 does not exist
+	r := &Reference{Book: "Mark", ChapterVerseRange: []ChapterVerseRange{{StartChapter: 99, EndChapter: 99, StartVerse: 1, EndVerse: 1, StartVerseSuffix: 'f'}}}
+	if _, err := r.ResolveFF(EndOfChapter{}); err == nil {
+		t.Errorf("expected error for a chapter that does not exist")
+	}
+}
+
+func TestWithFFResolver(t *testing.T) {
+	var got string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.FormValue("passage")
+		w.Write([]byte(minimalLection))
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		opts []Option
+		in   string
+		want string
+	}{
+		{nil, "mark 1:1ff", "mark 1:1ff"},
+		{nil, "gen 1:3-end", "Genesis 1:3-31"},
+		{[]Option{WithFFResolver(EndOfChapter{})}, "mark 1:1ff", "Mark 1:1-45"},
+		{[]Option{WithFFResolver(EndOfChapter{})}, "mark 1:1-8", "mark 1:1-8"},
+		{[]Option{WithFFResolver(Pericopes{"MRK": {{1, 1}, {1, 9}}})}, "mark 1:1ff", "Mark 1:1-8"},
+	}
+	for _, tc := range tests {
+		c := NewClient(append(tc.opts, WithBaseURL(ts.URL))...)
+		p, err := c.GetPassage(context.Background(), tc.in)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.in, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: sent %q, want %q", tc.in, got, tc.want)
+		}
+		if p.Reference != tc.want {
+			t.Errorf("%s: passage reference %q, want %q", tc.in, p.Reference, tc.want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package oremus
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultUserAgent identifies this package to oremus unless WithUserAgent is used
+const DefaultUserAgent = "go-oremus (+https://github.com/cloudkucooland/go-oremus)"
+
+// default backoff bounds used by WithRetries
+const (
+	defaultBackoffBase = 500 * time.Millisecond
+	defaultBackoffMax  = 30 * time.Second
+)
+
+// WithUserAgent sets the User-Agent header sent to oremus
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		if ua != "" {
+			c.userAgent = ua
+		}
+	}
+}
+
+// WithRateLimit allows at most perSecond requests per second on average, with bursts of up to burst requests
+// the limit is shared by everything using the Client, including GetMany's workers
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(c *Client) {
+		if perSecond > 0 {
+			c.limiter = newLimiter(perSecond, max(burst, 1))
+		}
+	}
+}
+
+// WithRetries retries requests that fail with a 5xx, a 429 or a network error up to n times
+func WithRetries(n int) Option {
+	return func(c *Client) {
+		c.retries = max(n, 0)
+	}
+}
+
+// WithBackoff sets the delay before the first retry and the most it may grow to; each retry doubles the delay, with jitter
+// a longer Retry-After from the server takes precedence, one beyond max gives up and returns the *HTTPStatusError
+func WithBackoff(base, max time.Duration) Option {
+	return func(c *Client) {
+		if base > 0 {
+			c.backoffBase = base
+		}
+		if max > 0 {
+			c.backoffMax = max
+		}
+	}
+}
+
+// limiter is a token bucket
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(rate float64, burst int) *limiter {
+	return &limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or the context is done
+func (l *limiter) wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// sleep waits for d or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// temporaryError marks a network failure that is worth retrying
+type temporaryError struct {
+	err error
+}
+
+func (e temporaryError) Error() string { return e.err.Error() }
+func (e temporaryError) Unwrap() error { return e.err }
+
+// temporary marks transport errors likely to go away on their own
+func temporary(err error) error {
+	if isTransient(err) {
+		return temporaryError{err}
+	}
+	return err
+}
+
+// retryable reports whether a failed request should be tried again
+func retryable(err error) bool {
+	var te temporaryError
+	if errors.As(err, &te) {
+		return true
+	}
+	var se *HTTPStatusError
+	if errors.As(err, &se) {
+		return se.StatusCode >= 500 || se.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// isTransient reports whether a transport error is likely to go away on its own
+func isTransient(err error) bool {
+	// *url.Error is itself a net.Error, look at what it wraps
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		err = ue.Err
+	}
+	var ne net.Error
+	return errors.As(err, &ne) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// backoff returns how long to wait before retry number attempt (starting at 0)
+// it reports false when the server asks us to wait longer than backoffMax
+func (c *Client) backoff(attempt int, err error) (time.Duration, bool) {
+	d := c.backoffBase << attempt
+	if d > c.backoffMax || d <= 0 {
+		d = c.backoffMax
+	}
+	// equal jitter: at least half the delay, so retries still back off
+	d = d/2 + rand.N(d/2+1)
+
+	var se *HTTPStatusError
+	if errors.As(err, &se) && se.RetryAfter > d {
+		if se.RetryAfter > c.backoffMax {
+			return 0, false
+		}
+		d = se.RetryAfter
+	}
+	return d, true
+}
+
+// parseRetryAfter reads a Retry-After header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(h string, now time.Time) time.Duration {
+	if h == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(h); err == nil {
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(h); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
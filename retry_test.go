@@ -0,0 +1,223 @@
+package oremus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer fails the first failures requests with fail, then serves a lection
+func flakyServer(t *testing.T, failures int32, fail func(w http.ResponseWriter)) (*httptest.Server, *atomic.Int32) {
+	var hits atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) <= failures {
+			fail(w)
+			return
+		}
+		w.Write([]byte(minimalLection))
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &hits
+}
+
+func TestRetry5xx(t *testing.T) {
+	ts, hits := flakyServer(t, 2, func(w http.ResponseWriter) {
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	})
+	c := NewClient(WithBaseURL(ts.URL), WithRetries(3), WithBackoff(time.Millisecond, 10*time.Millisecond))
+
+	if _, err := c.Get(context.Background(), "gen 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hits.Load() != 3 {
+		t.Errorf("expected 3 requests, got %d", hits.Load())
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	ts, hits := flakyServer(t, 10, func(w http.ResponseWriter) {
+		http.Error(w, "busy", http.StatusBadGateway)
+	})
+	c := NewClient(WithBaseURL(ts.URL), WithRetries(2), WithBackoff(time.Millisecond, 10*time.Millisecond))
+
+	_, err := c.Get(context.Background(), "gen 1")
+	var se *HTTPStatusError
+	if !errors.As(err, &se) || se.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected the last HTTPStatusError, got %v", err)
+	}
+	if hits.Load() != 3 {
+		t.Errorf("expected 3 requests, got %d", hits.Load())
+	}
+}
+
+func TestNoRetry4xx(t *testing.T) {
+	ts, hits := flakyServer(t, 1, func(w http.ResponseWriter) {
+		http.Error(w, "nope", http.StatusForbidden)
+	})
+	c := NewClient(WithBaseURL(ts.URL), WithRetries(3), WithBackoff(time.Millisecond, 10*time.Millisecond))
+
+	if _, err := c.Get(context.Background(), "gen 1"); err == nil {
+		t.Fatalf("expected error")
+	}
+	if hits.Load() != 1 {
+		t.Errorf("expected 1 request, got %d", hits.Load())
+	}
+}
+
+func TestRetryNetworkError(t *testing.T) {
+	ts, hits := flakyServer(t, 1, func(w http.ResponseWriter) {
+		// drop the connection without answering
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("hijack failed: %v", err)
+			return
+		}
+		conn.Close()
+	})
+	c := NewClient(WithBaseURL(ts.URL), WithRetries(1), WithBackoff(time.Millisecond, 10*time.Millisecond))
+
+	if _, err := c.Get(context.Background(), "gen 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hits.Load() != 2 {
+		t.Errorf("expected 2 requests, got %d", hits.Load())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	ts, hits := flakyServer(t, 1, func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	})
+	c := NewClient(WithBaseURL(ts.URL), WithRetries(1), WithBackoff(time.Millisecond, 2*time.Second))
+
+	start := time.Now()
+	if _, err := c.Get(context.Background(), "gen 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Retry-After not honored, retried after %s", elapsed)
+	}
+	if hits.Load() != 2 {
+		t.Errorf("expected 2 requests, got %d", hits.Load())
+	}
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+	ts, hits := flakyServer(t, 1, func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "86400")
+		http.Error(w, "come back tomorrow", http.StatusServiceUnavailable)
+	})
+	c := NewClient(WithBaseURL(ts.URL), WithRetries(3), WithBackoff(time.Millisecond, time.Second))
+
+	start := time.Now()
+	_, err := c.Get(context.Background(), "gen 1")
+	var se *HTTPStatusError
+	if !errors.As(err, &se) || se.RetryAfter != 24*time.Hour {
+		t.Fatalf("expected the HTTPStatusError, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("waited %s for a Retry-After beyond the backoff limit", elapsed)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("expected 1 request, got %d", hits.Load())
+	}
+}
+
+func TestRetryStopsOnCancel(t *testing.T) {
+	ts, hits := flakyServer(t, 100, func(w http.ResponseWriter) {
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	})
+	c := NewClient(WithBaseURL(ts.URL), WithRetries(100), WithBackoff(time.Second, time.Minute))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.Get(ctx, "gen 1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("retries continued for %s after cancellation", elapsed)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("expected 1 request, got %d", hits.Load())
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	ts, hits := flakyServer(t, 0, nil)
+	c := NewClient(WithBaseURL(ts.URL), WithRateLimit(20, 1))
+
+	start := time.Now()
+	for range 5 {
+		if _, err := c.Get(context.Background(), "gen 1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// the first is free, the other four wait 50ms each
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("rate limit not applied, 5 requests took %s", elapsed)
+	}
+	if hits.Load() != 5 {
+		t.Errorf("expected 5 requests, got %d", hits.Load())
+	}
+}
+
+func TestRateLimitCancel(t *testing.T) {
+	l := newLimiter(0.001, 1)
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	var got atomic.Value
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.Store(r.UserAgent())
+		w.Write([]byte(minimalLection))
+	}))
+	defer ts.Close()
+
+	if _, err := NewClient(WithBaseURL(ts.URL)).Get(context.Background(), "gen 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Load() != DefaultUserAgent {
+		t.Errorf("expected default user agent, got %q", got.Load())
+	}
+
+	if _, err := NewClient(WithBaseURL(ts.URL), WithUserAgent("lectionary/1.0")).Get(context.Background(), "gen 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Load() != "lectionary/1.0" {
+		t.Errorf("expected custom user agent, got %q", got.Load())
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Duration{
+		"":                              0,
+		"120":                           2 * time.Minute,
+		"-5":                            0,
+		"Mon, 01 Jan 2024 12:00:30 GMT": 30 * time.Second,
+		"Mon, 01 Jan 2024 11:00:00 GMT": 0,
+		"soon":                          0,
+	}
+	for in, want := range tests {
+		if got := parseRetryAfter(in, now); got != want {
+			t.Errorf("%q: got %s, want %s", in, got, want)
+		}
+	}
+}
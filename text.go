@@ -0,0 +1,124 @@
+package oremus
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TextRenderer renders a Passage as plain text
+// paragraphs are separated by blank lines, poetry keeps its line breaks and indentation
+type TextRenderer struct {
+	Width        int  // wrap lines at this many columns, 0 disables wrapping
+	Indent       int  // spaces per level of poetry indentation, wrapped poetry lines hang two levels deeper; 0 means 2
+	VerseNumbers bool // show verse numbers as [2] and chapter breaks as [3:1], the passage must be fetched WithVerseNumbers
+}
+
+// Render implements Renderer
+func (t TextRenderer) Render(p *Passage) string {
+	indent := t.Indent
+	if indent <= 0 {
+		indent = 2
+	}
+
+	var buf strings.Builder
+	for i, para := range p.Paragraphs {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		poetry := len(para.Lines) > 1
+		for _, line := range para.Lines {
+			first := strings.Repeat(" ", line.Indent*indent)
+			rest := first
+			if poetry {
+				// hang wrapped lines past the next level of indentation so they are not mistaken for new lines
+				rest += strings.Repeat(" ", 2*indent)
+			}
+			t.wrap(&buf, t.words(line), first, rest)
+		}
+	}
+	return buf.String()
+}
+
+// words splits a line into the units wrap lays out, a verse marker and the word after it are one unit
+// runs are joined as they are written, "LORD" and "'s" make "LORD's"
+func (t TextRenderer) words(line Line) []string {
+	var out []string
+	var word strings.Builder
+	marked := false // word holds a verse marker still waiting for the word after it
+	flush := func() {
+		if word.Len() > 0 {
+			out = append(out, word.String())
+			word.Reset()
+		}
+		marked = false
+	}
+	text := func(txt string) {
+		for _, r := range txt {
+			if unicode.IsSpace(r) {
+				if !marked {
+					flush()
+				}
+				continue
+			}
+			if marked {
+				word.WriteRune(' ')
+				marked = false
+			}
+			word.WriteRune(r)
+		}
+	}
+	marker := func(m string) {
+		flush()
+		word.WriteString(m)
+		marked = true
+	}
+	for _, r := range line.Runs {
+		switch r.Kind {
+		case VerseNumberRun:
+			if t.VerseNumbers {
+				marker("[" + strconv.Itoa(r.Number) + "]")
+			}
+		case ChapterNumberRun:
+			if t.VerseNumbers {
+				marker("[" + strconv.Itoa(r.Number) + ":1]")
+			}
+		case DivineNameRun:
+			text(strings.ToUpper(r.Text))
+		default:
+			text(r.Text)
+		}
+	}
+	flush()
+	return out
+}
+
+// wrap writes the words, breaking lines before they pass t.Width
+// the first output line is prefixed with first, the others with rest
+func (t TextRenderer) wrap(buf *strings.Builder, words []string, first string, rest string) {
+	if len(words) == 0 {
+		return
+	}
+
+	buf.WriteString(first)
+	col := utf8.RuneCountInString(first)
+	start := true
+	for _, w := range words {
+		wl := utf8.RuneCountInString(w)
+		if !start && t.Width > 0 && col+1+wl > t.Width {
+			buf.WriteString("\n")
+			buf.WriteString(rest)
+			col = utf8.RuneCountInString(rest)
+			start = true
+		}
+		if !start {
+			buf.WriteString(" ")
+			col++
+		}
+		buf.WriteString(w)
+		col += wl
+		start = false
+	}
+	buf.WriteString("\n")
+}
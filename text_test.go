@@ -0,0 +1,109 @@
+package oremus
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTextPoetry(t *testing.T) {
+	p := mustParse(t, fixture(t, "psalm-23.html"))
+	out := TextRenderer{Width: 40}.Render(p)
+
+	want := `The LORD is my shepherd, I shall not
+    want.
+  He makes me lie down in green
+      pastures;
+he leads me beside still waters;
+  he restores my soul.
+He leads me in right paths
+  for his name’s sake.
+
+Even though I walk through the darkest
+    valley,
+  I fear no evil;
+`
+	if !strings.HasPrefix(out, want) {
+		t.Errorf("got\n%s\nwant prefix\n%s", out, want)
+	}
+	if !strings.HasSuffix(out, "and I shall dwell in the house of the\n    LORD\n  my whole life long.\n") {
+		t.Errorf("unexpected end:\n%s", out)
+	}
+	if strings.Count(out, "\n\n") != 2 {
+		t.Errorf("expected 2 blank lines between 3 paragraphs:\n%s", out)
+	}
+}
+
+func TestTextProse(t *testing.T) {
+	p := mustParse(t, fixture(t, "genesis-1-1-5.html"))
+
+	out := TextRenderer{}.Render(p)
+	if strings.Count(out, "\n") != 1 || !strings.HasPrefix(out, "In the beginning when God") {
+		t.Errorf("expected a single unwrapped line, got %q", out)
+	}
+
+	out = TextRenderer{Width: 30}.Render(p)
+	for _, l := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		if len([]rune(l)) > 30 {
+			t.Errorf("line too long: %q", l)
+		}
+		if strings.HasPrefix(l, " ") {
+			t.Errorf("prose should not be indented: %q", l)
+		}
+	}
+}
+
+func TestTextVerseNumbers(t *testing.T) {
+	in := `<div class="bibletext"><p><span class="cc">2</span>Thus the heavens and the earth were finished. <sup class="ii">2</sup>And on the seventh day</p></div>`
+	p := mustParse(t, in)
+
+	if got := (TextRenderer{}).Render(p); got != "Thus the heavens and the earth were finished. And on the seventh day\n" {
+		t.Errorf("verse numbers should be off by default: %q", got)
+	}
+	if got := (TextRenderer{VerseNumbers: true}).Render(p); got != "[2:1] Thus the heavens and the earth were finished. [2] And on the seventh day\n" {
+		t.Errorf("unexpected verse numbers: %q", got)
+	}
+}
+
+func TestTextWrapVerseNumbers(t *testing.T) {
+	p := mustParse(t, fixture(t, "genesis-1-29-2-3-vnum.html"))
+	for _, width := range []int{20, 40, 60, 80} {
+		out := TextRenderer{Width: width, VerseNumbers: true}.Render(p)
+		for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+			if strings.HasSuffix(line, "]") {
+				t.Errorf("width %d: marker left at the end of a line: %q", width, line)
+			}
+			if utf8.RuneCountInString(line) > width {
+				t.Errorf("width %d: line too long: %q", width, line)
+			}
+		}
+		for _, want := range []string{"[30] And", "[31] God", "[2:1] Thus", "[3] So"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("width %d: expected %q in %q", width, want, out)
+			}
+		}
+	}
+
+	// private-use characters in the text are left alone
+	p = &Passage{Paragraphs: []Paragraph{{Lines: []Line{{Runs: []Run{
+		{Kind: VerseNumberRun, Number: 1},
+		{Kind: TextRun, Text: "a\ue000b \ue000"},
+	}}}}}}
+	if got := (TextRenderer{VerseNumbers: true}).Render(p); got != "[1] a\ue000b \ue000\n" {
+		t.Errorf("unexpected output %q", got)
+	}
+}
+
+func TestWithRenderer(t *testing.T) {
+	ts := fixtureServer(t, "psalm-23.html")
+	c := NewClient(WithBaseURL(ts.URL), WithRenderer(TextRenderer{}))
+
+	out, err := c.Get(context.Background(), "Psalm 23")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(out, "The LORD is my shepherd") {
+		t.Errorf("expected plain text, got %q", out)
+	}
+}
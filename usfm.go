@@ -0,0 +1,154 @@
+package oremus
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BookCode is the three-character USFM (Paratext) identifier of a book, e.g. "GEN", "1SA", "SIR"
+type BookCode string
+
+// bookCodes maps the prefixed canonical name of each book ("1 Samuel") to its USFM code
+var bookCodes = map[string]BookCode{
+	"Genesis":         "GEN",
+	"Exodus":          "EXO",
+	"Leviticus":       "LEV",
+	"Numbers":         "NUM",
+	"Deuteronomy":     "DEU",
+	"Joshua":          "JOS",
+	"Judges":          "JDG",
+	"Ruth":            "RUT",
+	"1 Samuel":        "1SA",
+	"2 Samuel":        "2SA",
+	"1 Kings":         "1KI",
+	"2 Kings":         "2KI",
+	"1 Chronicles":    "1CH",
+	"2 Chronicles":    "2CH",
+	"Ezra":            "EZR",
+	"Nehemiah":        "NEH",
+	"Esther":          "EST",
+	"Job":             "JOB",
+	"Psalms":          "PSA",
+	"Proverbs":        "PRO",
+	"Ecclesiastes":    "ECC",
+	"Song of Songs":   "SNG",
+	"Isaiah":          "ISA",
+	"Jeremiah":        "JER",
+	"Lamentations":    "LAM",
+	"Ezekiel":         "EZK",
+	"Daniel":          "DAN",
+	"Hosea":           "HOS",
+	"Joel":            "JOL",
+	"Amos":            "AMO",
+	"Obadiah":         "OBA",
+	"Jonah":           "JON",
+	"Micah":           "MIC",
+	"Nahum":           "NAM",
+	"Habakkuk":        "HAB",
+	"Zephaniah":       "ZEP",
+	"Haggai":          "HAG",
+	"Zechariah":       "ZEC",
+	"Malachi":         "MAL",
+	"Matthew":         "MAT",
+	"Mark":            "MRK",
+	"Luke":            "LUK",
+	"John":            "JHN",
+	"Acts":            "ACT",
+	"Romans":          "ROM",
+	"1 Corinthians":   "1CO",
+	"2 Corinthians":   "2CO",
+	"Galatians":       "GAL",
+	"Ephesians":       "EPH",
+	"Philippians":     "PHP",
+	"Colossians":      "COL",
+	"1 Thessalonians": "1TH",
+	"2 Thessalonians": "2TH",
+	"1 Timothy":       "1TI",
+	"2 Timothy":       "2TI",
+	"Titus":           "TIT",
+	"Philemon":        "PHM",
+	"Hebrews":         "HEB",
+	"James":           "JAS",
+	"1 Peter":         "1PE",
+	"2 Peter":         "2PE",
+	"1 John":          "1JN",
+	"2 John":          "2JN",
+	"3 John":          "3JN",
+	"Jude":            "JUD",
+	"Revelation":      "REV",
+
+	"Tobit":                  "TOB",
+	"Judith":                 "JDT",
+	"Additions to Esther":    "ESG",
+	"Wisdom":                 "WIS",
+	"Sirach":                 "SIR",
+	"Baruch":                 "BAR",
+	"Song of the Three Jews": "S3Y",
+	"Susanna":                "SUS",
+	"Bel and the Dragon":     "BEL",
+	"1 Maccabees":            "1MA",
+	"2 Maccabees":            "2MA",
+	"3 Maccabees":            "3MA",
+	"4 Maccabees":            "4MA",
+	"1 Esdras":               "1ES",
+	"2 Esdras":               "2ES",
+	"Prayer of Manasseh":     "MAN",
+}
+
+// bookCodeLookup maps each USFM code back to a book and prefix
+var bookCodeLookup map[BookCode]Reference
+
+func init() {
+	bookCodeLookup = make(map[BookCode]Reference)
+	for name, code := range bookCodes {
+		r := Reference{Book: name}
+		if len(name) > 2 && name[1] == ' ' {
+			r.Prefix = rune(name[0])
+			r.Book = name[2:]
+		}
+		bookCodeLookup[code] = r
+	}
+}
+
+// Book returns the canonical book name and prefix for the code, e.g. "Samuel" and '1' for "1SA"
+func (c BookCode) Book() (book string, prefix rune, ok bool) {
+	r, ok := bookCodeLookup[BookCode(strings.ToUpper(string(c)))]
+	return r.Book, r.Prefix, ok
+}
+
+// BookCode returns the USFM code of the reference's book
+func (r *Reference) BookCode() (BookCode, bool) {
+	name := r.bookName()
+	if name == "Psalm" {
+		name = "Psalms"
+	}
+	code, ok := bookCodes[name]
+	return code, ok
+}
+
+// USFM returns the reference with the book as a USFM code, e.g. "1SA 3:1-10"
+func (r *Reference) USFM() (string, error) {
+	code, ok := r.BookCode()
+	if !ok {
+		return "", fmt.Errorf("no USFM code for %s", r.bookName())
+	}
+
+	var buf strings.Builder
+	buf.WriteString(string(code))
+	if r.crossesBooks() {
+		_, end := r.endpoints()
+		endCode, ok := end.BookCode()
+		if !ok {
+			return "", fmt.Errorf("no USFM code for %s", end.bookName())
+		}
+		cv := r.ChapterVerseRange[0]
+		buf.WriteRune(' ')
+		writePoint(&buf, cv.StartChapter, cv.StartVerse, cv.StartVerseSuffix)
+		buf.WriteString("-" + string(endCode) + " ")
+		writePoint(&buf, cv.EndChapter, cv.EndVerse, cv.EndVerseSuffix)
+		return buf.String(), nil
+	}
+	// USFM has no notation for optional verses, they are written as part of the passage
+	r.IncludeOptional().writeChapterVerse(&buf)
+	return buf.String(), nil
+}
@@ -0,0 +1,99 @@
+package oremus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBookCode(t *testing.T) {
+	tests := []struct {
+		code   BookCode
+		book   string
+		prefix rune
+	}{
+		{"GEN", "Genesis", unset},
+		{"1SA", "Samuel", '1'},
+		{"3JN", "John", '3'},
+		{"PSA", "Psalms", unset},
+		{"SIR", "Sirach", unset},
+		{"4MA", "Maccabees", '4'},
+		{"JUD", "Jude", unset},
+		{"jhn", "John", unset},
+	}
+	for _, tc := range tests {
+		book, prefix, ok := tc.code.Book()
+		if !ok || book != tc.book || prefix != tc.prefix {
+			t.Errorf("%s: got %q %q %v, want %q %q", tc.code, book, prefix, ok, tc.book, tc.prefix)
+		}
+
+		r := Reference{Book: tc.book, Prefix: tc.prefix}
+		code, ok := r.BookCode()
+		if want := BookCode(strings.ToUpper(string(tc.code))); !ok || code != want {
+			t.Errorf("%s: got code %q %v", r.bookName(), code, ok)
+		}
+	}
+
+	if _, _, ok := BookCode("XYZ").Book(); ok {
+		t.Errorf("expected XYZ to be unknown")
+	}
+	if code, _ := (&Reference{Book: "Psalm"}).BookCode(); code != "PSA" {
+		t.Errorf("expected Psalm to be PSA, got %q", code)
+	}
+}
+
+func TestBookCodesComplete(t *testing.T) {
+	for name := range versification {
+		if name == "Psalm" {
+			continue
+		}
+		if _, ok := bookCodes[name]; !ok {
+			t.Errorf("no USFM code for %s", name)
+		}
+	}
+	if len(bookCodeLookup) != len(bookCodes) {
+		t.Errorf("duplicate USFM codes")
+	}
+}
+
+func TestUSFM(t *testing.T) {
+	tests := map[string]string{
+		"1SA 3:1-10":        "1SA 3:1-10",
+		"1 samuel 3:1-10":   "1SA 3:1-10",
+		"1sa 3:1-10,19":     "1SA 3:1-10,19",
+		"gen 1:1-2:4a":      "GEN 1:1-2:4a",
+		"JUD 3":             "JDG 3",
+		"jud 3":             "JDG 3",
+		"jude 3":            "JUD 1:3",
+		"psalm 23":          "PSA 23",
+		"SIR 44:1-15":       "SIR 44:1-15",
+		"1 macc 2:1-28":     "1MA 2:1-28",
+		"REV":               "REV",
+		"S3Y 29-34":         "S3Y 1:29-34",
+		"MAT 5:1-12; LUK 6": "MAT 5:1-12; LUK 6",
+	}
+	for in, want := range tests {
+		refs, err := ParseReferences(in)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", in, err)
+		}
+		var got string
+		for i, r := range refs {
+			s, err := r.USFM()
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", in, err)
+			}
+			if i > 0 {
+				got += "; "
+			}
+			got += s
+		}
+		if got != want {
+			t.Errorf("%s: got %q, want %q", in, got, want)
+		}
+	}
+
+	s, err := CleanReference("1SA 3:1-10")
+	if err != nil || s != "1 Samuel 3:1-10" {
+		t.Errorf("expected 1SA to read as 1 Samuel, got %q %v", s, err)
+	}
+}
@@ -0,0 +1,67 @@
+package oremus
+
+import (
+	"iter"
+	"strconv"
+)
+
+// Verse is a single verse of a book
+type Verse struct {
+	Book    string
+	Prefix  rune
+	Chapter int
+	Verse   int
+	Part    rune // 'a' or 'b' when only half of the verse is included
+}
+
+// String returns the verse as a normalized reference, e.g. "1 John 4:8" or "Mark 1:1a"
+func (v Verse) String() string {
+	r := Reference{Book: v.Book, Prefix: v.Prefix}
+	s := r.bookName() + " " + strconv.Itoa(v.Chapter) + ":" + strconv.Itoa(v.Verse)
+	if v.Part != unset {
+		s += string(v.Part)
+	}
+	return s
+}
+
+// Verses iterates over every verse the reference covers, in the order of its ranges
+// whole books, whole chapters and ff are expanded with the versification tables; a range with an a or b suffix
+// yields that half of its first or last verse; a reference that fails ValidateExists yields nothing
+func (r *Reference) Verses() iter.Seq[Verse] {
+	return func(yield func(Verse) bool) {
+		parts, err := r.Split()
+		if err != nil || r.ValidateExists() != nil {
+			return
+		}
+		for _, r := range parts {
+			if !r.yieldVerses(yield) {
+				return
+			}
+		}
+	}
+}
+
+// yieldVerses passes each verse of a single-book reference to yield, reporting whether to continue
+func (r *Reference) yieldVerses(yield func(Verse) bool) bool {
+	v := newVersification(r)
+	for _, sp := range v.spans(r) {
+		for n := sp.lo; n <= sp.hi; {
+			chapter, verse, half := v.position(n)
+			out := Verse{Book: r.Book, Prefix: r.Prefix, Chapter: chapter, Verse: verse}
+			switch {
+			case half == 1:
+				out.Part = 'b'
+				n++
+			case n == sp.hi:
+				out.Part = 'a'
+				n++
+			default:
+				n += 2
+			}
+			if !yield(out) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package oremus
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReferenceVerses(t *testing.T) {
+	tests := map[string][]string{
+		"gen 1:1":        {"Genesis 1:1"},
+		"gen 1:1-3":      {"Genesis 1:1", "Genesis 1:2", "Genesis 1:3"},
+		"gen 1:30-2:2":   {"Genesis 1:30", "Genesis 1:31", "Genesis 2:1", "Genesis 2:2"},
+		"ps 117":         {"Psalms 117:1", "Psalms 117:2"},
+		"ps 133-134":     {"Psalms 133:1", "Psalms 133:2", "Psalms 133:3", "Psalms 134:1", "Psalms 134:2", "Psalms 134:3"},
+		"gen 1:29ff":     {"Genesis 1:29", "Genesis 1:30", "Genesis 1:31"},
+		"mark 1:1a":      {"Mark 1:1a"},
+		"mark 1:1b":      {"Mark 1:1b"},
+		"mark 1:2b-4a":   {"Mark 1:2b", "Mark 1:3", "Mark 1:4a"},
+		"gen 2:4b-5,1:1": {"Genesis 2:4b", "Genesis 2:5", "Genesis 1:1"},
+		"1 john 5:20-21": {"1 John 5:20", "1 John 5:21"},
+		"gen 51":         nil,
+	}
+	for in, want := range tests {
+		r, err := ParseReference(in)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", in, err)
+		}
+		var got []string
+		for v := range r.Verses() {
+			got = append(got, v.String())
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: got %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestReferenceVersesWholeBook(t *testing.T) {
+	r, err := ParseReference("genesis")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var n int
+	var last Verse
+	for v := range r.Verses() {
+		n++
+		last = v
+	}
+	if n != 1533 {
+		t.Errorf("expected 1533 verses in Genesis, got %d", n)
+	}
+	if last != (Verse{Book: "Genesis", Chapter: 50, Verse: 26}) {
+		t.Errorf("wrong last verse %v", last)
+	}
+
+	// stopping early
+	n = 0
+	for range r.Verses() {
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Errorf("expected to stop after 3 verses, got %d", n)
+	}
+}
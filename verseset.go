@@ -0,0 +1,314 @@
+package oremus
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// VerseSet is a set of verses, possibly from several books, supporting set arithmetic
+// verses are tracked in halves so that "Mark 1:1a" and "Mark 1:1b" are distinct; the zero value is an empty set
+type VerseSet struct {
+	books map[BookCode][]span
+}
+
+// span is an inclusive range of half-verses, numbered from the start of the book
+type span struct {
+	lo, hi int
+}
+
+// ParseVerseSet parses a semi-colon separated list of references into a VerseSet
+func ParseVerseSet(in string) (*VerseSet, error) {
+	refs, err := ParseReferences(in)
+	if err != nil {
+		return nil, err
+	}
+	return NewVerseSet(refs...)
+}
+
+// NewVerseSet returns the set of verses covered by the references
+// whole books, whole chapters and ff are expanded using the versification tables, so every verse must exist
+func NewVerseSet(refs ...*Reference) (*VerseSet, error) {
+	refs, err := splitReferences(refs)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &VerseSet{books: make(map[BookCode][]span)}
+	for _, r := range refs {
+		code, ok := r.BookCode()
+		if !ok {
+			return nil, fmt.Errorf("no versification for %s", r.bookName())
+		}
+		if err := r.ValidateExists(); err != nil {
+			return nil, err
+		}
+
+		s.books[code] = union(s.books[code], newVersification(r).spans(r))
+	}
+	return s, nil
+}
+
+// Empty reports whether the set has no verses
+func (s *VerseSet) Empty() bool {
+	for _, spans := range s.books {
+		if len(spans) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains reports whether every verse of other is in the set
+func (s *VerseSet) Contains(other *VerseSet) bool {
+	return other.Subtract(s).Empty()
+}
+
+// Overlaps reports whether the sets have any verse in common
+func (s *VerseSet) Overlaps(other *VerseSet) bool {
+	return !s.Intersect(other).Empty()
+}
+
+// Union returns the verses in either set
+func (s *VerseSet) Union(other *VerseSet) *VerseSet {
+	out := &VerseSet{books: maps.Clone(s.books)}
+	if out.books == nil {
+		out.books = make(map[BookCode][]span)
+	}
+	for code, spans := range other.books {
+		out.books[code] = union(out.books[code], spans)
+	}
+	return out
+}
+
+// Intersect returns the verses in both sets
+func (s *VerseSet) Intersect(other *VerseSet) *VerseSet {
+	out := &VerseSet{books: make(map[BookCode][]span)}
+	for code, spans := range s.books {
+		if is := intersect(spans, other.books[code]); len(is) > 0 {
+			out.books[code] = is
+		}
+	}
+	return out
+}
+
+// Subtract returns the verses in the set that are not in other
+func (s *VerseSet) Subtract(other *VerseSet) *VerseSet {
+	out := &VerseSet{books: make(map[BookCode][]span)}
+	for code, spans := range s.books {
+		if diff := subtract(spans, other.books[code]); len(diff) > 0 {
+			out.books[code] = diff
+		}
+	}
+	return out
+}
+
+// References returns the fewest references that cover the set, one per book in canonical order
+// whole books and whole chapters are written without verses
+func (s *VerseSet) References() []*Reference {
+	codes := slices.Collect(maps.Keys(s.books))
+	slices.SortFunc(codes, func(a, b BookCode) int {
+		return cmp.Compare(ordinals[Protestant][a], ordinals[Protestant][b])
+	})
+
+	var out []*Reference
+	for _, code := range codes {
+		spans := s.books[code]
+		if len(spans) == 0 {
+			continue
+		}
+		book := bookCodeLookup[code]
+		r := &Reference{Book: book.Book, Prefix: book.Prefix}
+		v := newVersification(r)
+		if len(spans) == 1 && spans[0] == v.all() {
+			out = append(out, r)
+			continue
+		}
+		for _, sp := range spans {
+			r.ChapterVerseRange = append(r.ChapterVerseRange, v.chapterVerseRange(sp))
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// String returns the normalized references of the set, separated by semi-colons
+func (s *VerseSet) String() string {
+	refs := s.References()
+	out := make([]string, len(refs))
+	for i, r := range refs {
+		out[i] = r.String()
+	}
+	return strings.Join(out, "; ")
+}
+
+// bookVersification converts between chapter:verse and half-verse numbers within a book
+type bookVersification struct {
+	first    int   // number of the first chapter
+	chapters []int // verses in each chapter
+	offsets  []int // half-verse number of the first verse of each chapter
+}
+
+// newVersification returns the versification of the reference's book, which must exist
+func newVersification(r *Reference) bookVersification {
+	chapters, _ := r.chapters()
+	first, _ := r.chapterRange()
+	v := bookVersification{first: first, chapters: chapters, offsets: make([]int, len(chapters))}
+	total := 0
+	for i, n := range chapters {
+		v.offsets[i] = total
+		total += 2 * n
+	}
+	return v
+}
+
+// all returns the span of the whole book
+func (v bookVersification) all() span {
+	last := len(v.chapters) - 1
+	return span{0, v.offsets[last] + 2*v.chapters[last] - 1}
+}
+
+// half returns the number of the half-verse, half is 0 for the a half and 1 for the b half
+func (v bookVersification) half(chapter, verse, half int) int {
+	return v.offsets[chapter-v.first] + 2*(verse-1) + half
+}
+
+// lastVerse returns the number of verses in the chapter
+func (v bookVersification) lastVerse(chapter int) int {
+	return v.chapters[chapter-v.first]
+}
+
+// spans returns the half-verses covered by each range of the reference, in order
+func (v bookVersification) spans(r *Reference) []span {
+	if len(r.ChapterVerseRange) == 0 {
+		return []span{v.all()}
+	}
+	out := make([]span, len(r.ChapterVerseRange))
+	for i, cv := range r.ChapterVerseRange {
+		out[i] = v.all()
+		if cv.StartChapter != 0 {
+			out[i] = v.span(cv)
+		}
+	}
+	return out
+}
+
+// span returns the half-verses covered by a range
+func (v bookVersification) span(cv ChapterVerseRange) span {
+	startVerse, startHalf := max(cv.StartVerse, 1), 0
+	if cv.StartVerseSuffix == 'b' {
+		startHalf = 1
+	}
+
+	endVerse, endHalf := cv.EndVerse, 1
+	if endVerse == 0 {
+		endVerse = v.lastVerse(cv.EndChapter)
+	}
+	switch {
+	case cv.EndVerseSuffix == 'a':
+		endHalf = 0
+	case cv.EndVerseSuffix == 'f', cv.StartVerseSuffix == 'f' && cv.EndVerse == cv.StartVerse && cv.EndChapter == cv.StartChapter:
+		endVerse = v.lastVerse(cv.EndChapter)
+	case cv.StartVerseSuffix == 'a' && cv.EndVerse == cv.StartVerse && cv.EndChapter == cv.StartChapter:
+		// a single half verse, Mark 1:1a
+		endHalf = 0
+	}
+
+	return span{v.half(cv.StartChapter, startVerse, startHalf), v.half(cv.EndChapter, endVerse, endHalf)}
+}
+
+// position returns the chapter, verse and half of a half-verse number
+func (v bookVersification) position(n int) (chapter, verse, half int) {
+	i, found := slices.BinarySearch(v.offsets, n)
+	if !found {
+		i--
+	}
+	rest := n - v.offsets[i]
+	return i + v.first, rest/2 + 1, rest % 2
+}
+
+// chapterVerseRange converts a span back to a range, using whole chapters where possible
+func (v bookVersification) chapterVerseRange(sp span) ChapterVerseRange {
+	startChapter, startVerse, startHalf := v.position(sp.lo)
+	endChapter, endVerse, endHalf := v.position(sp.hi)
+
+	cv := ChapterVerseRange{StartChapter: startChapter, EndChapter: endChapter}
+	if startVerse == 1 && startHalf == 0 && endVerse == v.lastVerse(endChapter) && endHalf == 1 {
+		return cv
+	}
+
+	cv.StartVerse, cv.EndVerse = startVerse, endVerse
+	single := startChapter == endChapter && startVerse == endVerse
+	switch {
+	case single && startHalf == 1:
+		cv.StartVerseSuffix = 'b'
+	case single && endHalf == 0:
+		cv.StartVerseSuffix = 'a'
+	case !single:
+		if startHalf == 1 {
+			cv.StartVerseSuffix = 'b'
+		}
+		if endHalf == 0 {
+			cv.EndVerseSuffix = 'a'
+		}
+	}
+	return cv
+}
+
+// union merges two sorted lists of spans, joining adjacent spans
+func union(a, b []span) []span {
+	all := slices.Concat(a, b)
+	slices.SortFunc(all, func(x, y span) int { return cmp.Compare(x.lo, y.lo) })
+
+	var out []span
+	for _, sp := range all {
+		if n := len(out); n > 0 && sp.lo <= out[n-1].hi+1 {
+			out[n-1].hi = max(out[n-1].hi, sp.hi)
+			continue
+		}
+		out = append(out, sp)
+	}
+	return out
+}
+
+// intersect returns the half-verses in both sorted lists of spans
+func intersect(a, b []span) []span {
+	var out []span
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		lo, hi := max(a[i].lo, b[j].lo), min(a[i].hi, b[j].hi)
+		if lo <= hi {
+			out = append(out, span{lo, hi})
+		}
+		if a[i].hi < b[j].hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return out
+}
+
+// subtract returns the half-verses of a that are not in b, both sorted lists of spans
+func subtract(a, b []span) []span {
+	var out []span
+	for _, sp := range a {
+		for _, cut := range b {
+			if cut.hi < sp.lo || cut.lo > sp.hi {
+				continue
+			}
+			if cut.lo > sp.lo {
+				out = append(out, span{sp.lo, cut.lo - 1})
+			}
+			sp.lo = cut.hi + 1
+			if sp.lo > sp.hi {
+				break
+			}
+		}
+		if sp.lo <= sp.hi {
+			out = append(out, sp)
+		}
+	}
+	return out
+}
@@ -0,0 +1,132 @@
+package oremus
+
+import (
+	"testing"
+)
+
+func mustVerseSet(t *testing.T, in string) *VerseSet {
+	t.Helper()
+	s, err := ParseVerseSet(in)
+	if err != nil {
+		t.Fatalf("%s: unexpected error: %v", in, err)
+	}
+	return s
+}
+
+func TestVerseSetString(t *testing.T) {
+	tests := map[string]string{
+		"gen 1:1-5":                         "Genesis 1:1-5",
+		"gen 1:1-5; gen 1:6-10":             "Genesis 1:1-10",
+		"gen 1:1-5,3-8":                     "Genesis 1:1-8",
+		"gen 1:1-31":                        "Genesis 1",
+		"gen 1:1-2:25":                      "Genesis 1-2",
+		"gen 1:26ff":                        "Genesis 1:26-31",
+		"gen 1:30-2:3":                      "Genesis 1:30-2:3",
+		"genesis":                           "Genesis",
+		"gen 1-50":                          "Genesis",
+		"jude 1-25":                         "Jude",
+		"mark 1:1a":                         "Mark 1:1a",
+		"mark 1:1b":                         "Mark 1:1b",
+		"mark 1:1a; mark 1:1b":              "Mark 1:1",
+		"mark 1:1-8a":                       "Mark 1:1-8a",
+		"mark 1:4b-8":                       "Mark 1:4b-8",
+		"luke 15:1-10; gen 1:1":             "Genesis 1:1; Luke 15:1-10",
+		"ps 23; psalm 24":                   "Psalms 23-24",
+		"add esth 10:4-11:12":               "Additions to Esther 10:4-11:12",
+		"add esth 10-16":                    "Additions to Esther",
+		"luke 15:1-3,11b-32; luke 15:4-11a": "Luke 15",
+	}
+	for in, want := range tests {
+		if got := mustVerseSet(t, in).String(); got != want {
+			t.Errorf("%s: got %q, want %q", in, got, want)
+		}
+	}
+
+	if _, err := ParseVerseSet("gen 51"); err == nil {
+		t.Errorf("expected error for a chapter that does not exist")
+	}
+	var empty VerseSet
+	if !empty.Empty() || empty.String() != "" {
+		t.Errorf("expected the zero value to be empty")
+	}
+}
+
+func TestVerseSetArithmetic(t *testing.T) {
+	tests := []struct {
+		a, b                       string
+		union, intersect, subtract string
+		contains, overlaps         bool
+	}{
+		{
+			a: "luke 15", b: "luke 15:1-3,11b-32",
+			union: "Luke 15", intersect: "Luke 15:1-3,11b-32", subtract: "Luke 15:4-11a",
+			contains: true, overlaps: true,
+		},
+		{
+			a: "mark 1:1-8", b: "mark 1:9-15",
+			union: "Mark 1:1-15", intersect: "", subtract: "Mark 1:1-8",
+			contains: false, overlaps: false,
+		},
+		{
+			a: "gen 1:1-2:4a", b: "gen 2:4b-25",
+			union: "Genesis 1-2", intersect: "", subtract: "Genesis 1:1-2:4a",
+			contains: false, overlaps: false,
+		},
+		{
+			a: "gen 1:26-2:3", b: "gen 1:31-2:10",
+			union: "Genesis 1:26-2:10", intersect: "Genesis 1:31-2:3", subtract: "Genesis 1:26-30",
+			contains: false, overlaps: true,
+		},
+		{
+			a: "john 1:1-14", b: "1 john 1:1-4",
+			union: "John 1:1-14; 1 John 1:1-4", intersect: "", subtract: "John 1:1-14",
+			contains: false, overlaps: false,
+		},
+		{
+			a: "ps 1-150", b: "ps 23:1",
+			union: "Psalms 1-150", intersect: "Psalms 23:1", subtract: "Psalms 1-22,23:2-150:6",
+			contains: true, overlaps: true,
+		},
+	}
+	for _, tc := range tests {
+		a, b := mustVerseSet(t, tc.a), mustVerseSet(t, tc.b)
+		if got := a.Union(b).String(); got != tc.union {
+			t.Errorf("%s union %s: got %q, want %q", tc.a, tc.b, got, tc.union)
+		}
+		if got := b.Union(a).String(); got != tc.union {
+			t.Errorf("%s union %s: got %q, want %q", tc.b, tc.a, got, tc.union)
+		}
+		if got := a.Intersect(b).String(); got != tc.intersect {
+			t.Errorf("%s intersect %s: got %q, want %q", tc.a, tc.b, got, tc.intersect)
+		}
+		if got := a.Subtract(b).String(); got != tc.subtract {
+			t.Errorf("%s subtract %s: got %q, want %q", tc.a, tc.b, got, tc.subtract)
+		}
+		if got := a.Contains(b); got != tc.contains {
+			t.Errorf("%s contains %s: got %v", tc.a, tc.b, got)
+		}
+		if got := a.Overlaps(b); got != tc.overlaps {
+			t.Errorf("%s overlaps %s: got %v", tc.a, tc.b, got)
+		}
+		if got := b.Overlaps(a); got != tc.overlaps {
+			t.Errorf("%s overlaps %s: got %v", tc.b, tc.a, got)
+		}
+	}
+}
+
+func TestVerseSetRoundTrip(t *testing.T) {
+	tests := []string{
+		"Genesis 1:1-2:4a",
+		"Luke 15:1-3,11b-32",
+		"Psalms 1-22,23:2-150:6",
+		"Mark 1:1a",
+		"Isaiah 40:1-11; Mark 1:1-8",
+	}
+	for _, in := range tests {
+		s := mustVerseSet(t, in)
+		again := mustVerseSet(t, s.String())
+		if s.String() != in || !s.Contains(again) || !again.Contains(s) {
+			t.Errorf("%s: round-tripped to %q", in, s.String())
+		}
+	}
+}
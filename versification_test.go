@@ -0,0 +1,98 @@
+package oremus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVersificationComplete(t *testing.T) {
+	for book := range books {
+		if _, ok := booksWithPrefix[strings.ToLower(book)]; ok {
+			continue
+		}
+		if _, ok := versification[book]; !ok {
+			t.Errorf("no versification for %s", book)
+		}
+	}
+	for book, prefixes := range booksWithPrefix {
+		for _, p := range prefixes {
+			r := Reference{Book: bookLookup[book], Prefix: p}
+			if _, ok := r.chapters(); !ok {
+				t.Errorf("no versification for %s", r.bookName())
+			}
+		}
+	}
+	for book, chapters := range versification {
+		for i, n := range chapters {
+			if n <= 0 {
+				t.Errorf("%s %d has no verses", book, i+1)
+			}
+		}
+	}
+}
+
+func TestValidateExists(t *testing.T) {
+	good := []string{
+		"gen 1:1",
+		"gen 50:26",
+		"gen 50",
+		"genesis",
+		"ps 119:176",
+		"psalm 150",
+		"1 john 5:21",
+		"3 john 15",
+		"jude 25",
+		"obad 21",
+		"rev 22:21",
+		"gen 1:1-50:26",
+		"gen 1:31-2:4",
+		"gen 1:26ff",
+		"1 cor 13:1-13",
+		"wis 19:22",
+		"ps 151:7",
+		"sir 51:30",
+		"4 macc 18:24",
+		"2 esd 7:140",
+		"add esth 10:4-16:24",
+		"pr man 15",
+		"sus 64",
+		"bel 42",
+	}
+	for _, in := range good {
+		r, err := ParseReference(in)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error: %v", in, err)
+		}
+		if err := r.ValidateExists(); err != nil {
+			t.Errorf("%s: unexpected error: %v", in, err)
+		}
+	}
+
+	bad := map[string]string{
+		"jude 7:99":      "Jude has 1 chapter",
+		"genesis 51":     "Genesis has 50 chapters",
+		"gen 50:27":      "Genesis 50 has 26 verses",
+		"gen 1:1-2:99":   "Genesis 2 has 25 verses",
+		"ps 152":         "Psalms has 151 chapters",
+		"add esth 9":     "Additions to Esther starts at chapter 10",
+		"add esth 16:25": "Additions to Esther 16 has 24 verses",
+		"3 macc 8":       "3 Maccabees has 7 chapters",
+		"sus 65":         "Susanna 1 has 64 verses",
+		"2 john 1:14":    "2 John 1 has 13 verses",
+		"gen 1:1,40":     "Genesis 1 has 31 verses",
+	}
+	for in, want := range bad {
+		r, err := ParseReference(in)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error: %v", in, err)
+		}
+		err = r.ValidateExists()
+		if err == nil {
+			t.Errorf("%s: expected error", in)
+			continue
+		}
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: error %q does not mention %q", in, err, want)
+		}
+	}
+}
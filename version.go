@@ -0,0 +1,143 @@
+package oremus
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Version is a translation served by bible.oremus.org
+type Version int
+
+const (
+	NRSV       Version = iota // New Revised Standard Version, the oremus default
+	NRSVAE                    // NRSV Anglicized Edition
+	AV                        // Authorized (King James) Version
+	BCPPsalter                // Coverdale psalter from the 1662 Book of Common Prayer
+	CWPsalter                 // Common Worship psalter
+)
+
+// versions maps each Version to the value of the oremus "version" form field
+var versions = map[Version]string{
+	NRSV:       "NRSV",
+	NRSVAE:     "NRSVAE",
+	AV:         "AV",
+	BCPPsalter: "BCP",
+	CWPsalter:  "CW",
+}
+
+// known variations of version names (lowercase for ease of matching)
+var versionNames = map[Version][]string{
+	NRSV:       {"nrsv"},
+	NRSVAE:     {"nrsvae", "nrsva", "nrsv anglicized", "nrsv anglicised"},
+	AV:         {"av", "kjv", "authorized version", "authorised version", "king james"},
+	BCPPsalter: {"bcp", "bcp psalter", "coverdale"},
+	CWPsalter:  {"cw", "cw psalter", "common worship"},
+}
+
+// deuterocanonical books, only the NRSV editions on oremus include them
+var apocrypha = map[string]bool{
+	"Tobit":                  true,
+	"Judith":                 true,
+	"Additions to Esther":    true,
+	"Wisdom":                 true,
+	"Sirach":                 true,
+	"Baruch":                 true,
+	"Song of the Three Jews": true,
+	"Susanna":                true,
+	"Bel and the Dragon":     true,
+	"Maccabees":              true,
+	"Esdras":                 true,
+	"Prayer of Manasseh":     true,
+}
+
+// psalm151 is the chapter of Psalms found only in the NRSV's Apocrypha
+const psalm151 = 151
+
+// String returns the oremus name of the version
+func (v Version) String() string {
+	if s, ok := versions[v]; ok {
+		return s
+	}
+	return fmt.Sprintf("Version(%d)", int(v))
+}
+
+// ParseVersion converts a version name such as "NRSV" or "kjv" to a Version
+func ParseVersion(in string) (Version, error) {
+	lc := strings.ToLower(strings.TrimSpace(in))
+	for v, names := range versionNames {
+		for _, n := range names {
+			if n == lc {
+				return v, nil
+			}
+		}
+	}
+	return NRSV, fmt.Errorf("unknown version %q", in)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (v Version) MarshalText() ([]byte, error) {
+	if _, ok := versions[v]; !ok {
+		return nil, fmt.Errorf("unknown version %d", int(v))
+	}
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (v *Version) UnmarshalText(b []byte) error {
+	parsed, err := ParseVersion(string(b))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// IsPsalter reports whether the version contains only the psalms
+func (v Version) IsPsalter() bool {
+	return v == BCPPsalter || v == CWPsalter
+}
+
+// hasApocrypha reports whether the version includes the deuterocanonical books
+func (v Version) hasApocrypha() bool {
+	return v == NRSV || v == NRSVAE
+}
+
+// Contains reports whether the version includes the (canonical) book
+func (v Version) Contains(book string) bool {
+	switch {
+	case v.IsPsalter():
+		return book == "Psalm" || book == "Psalms"
+	case apocrypha[book]:
+		return v.hasApocrypha()
+	default:
+		_, ok := versions[v]
+		return ok
+	}
+}
+
+// ValidateVersion checks that the book of the reference is available in the version
+func (r *Reference) ValidateVersion(v Version) error {
+	if !v.Contains(r.Book) {
+		return fmt.Errorf("%s is not available in the %s", r.bookName(), v)
+	}
+	if r.crossesBooks() {
+		parts, err := r.Split()
+		if err != nil {
+			return err
+		}
+		for _, p := range parts {
+			if err := p.ValidateVersion(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if r.Book == "Psalm" || r.Book == "Psalms" {
+		for _, cv := range r.ChapterVerseRange {
+			if cv.EndChapter >= psalm151 && !v.hasApocrypha() {
+				return fmt.Errorf("Psalm 151 is not available in the %s", v)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package oremus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := map[string]Version{
+		"NRSV":           NRSV,
+		"nrsvae":         NRSVAE,
+		"KJV":            AV,
+		" av ":           AV,
+		"Common Worship": CWPsalter,
+		"coverdale":      BCPPsalter,
+	}
+	for in, want := range tests {
+		got, err := ParseVersion(in)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: got %s, want %s", in, got, want)
+		}
+	}
+
+	if _, err := ParseVersion("esv"); err == nil {
+		t.Errorf("expected error for unknown version")
+	}
+}
+
+func TestValidateVersion(t *testing.T) {
+	tests := []struct {
+		ref     string
+		version Version
+		ok      bool
+	}{
+		{"gen 1", NRSV, true},
+		{"gen 1", AV, true},
+		{"gen 1", BCPPsalter, false},
+		{"ps 23", CWPsalter, true},
+		{"psalm 23", BCPPsalter, true},
+		{"wis 1", NRSVAE, true},
+		{"wis 1", AV, false},
+		{"1 john 4:8", CWPsalter, false},
+		{"sir 44:1-15", NRSV, true},
+		{"2 macc 7:1", AV, false},
+		{"ps 151", NRSV, true},
+		{"ps 150-151", AV, false},
+		{"ps 151", BCPPsalter, false},
+	}
+	for _, tc := range tests {
+		r, err := ParseReference(tc.ref)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		err = r.ValidateVersion(tc.version)
+		if tc.ok && err != nil {
+			t.Errorf("%s in %s: unexpected error %v", tc.ref, tc.version, err)
+		}
+		if !tc.ok && err == nil {
+			t.Errorf("%s in %s: expected error", tc.ref, tc.version)
+		}
+	}
+}
+
+func TestGetVersion(t *testing.T) {
+	var got string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.FormValue("version")
+		w.Write([]byte(minimalLection))
+	}))
+	defer ts.Close()
+
+	c := NewClient(WithBaseURL(ts.URL), WithVersion(BCPPsalter))
+	p, err := c.GetPassage(context.Background(), "Psalm 23")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "BCP" {
+		t.Errorf("expected version=BCP, got %q", got)
+	}
+	if p.Version != BCPPsalter {
+		t.Errorf("passage version not set: %s", p.Version)
+	}
+
+	if _, err := c.Get(context.Background(), "Genesis 1"); err == nil {
+		t.Errorf("expected error fetching Genesis from a psalter")
+	}
+}